@@ -0,0 +1,181 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+// Assert provides diff-aware assertions reporting failures to a test using
+// the same diff configuration and output format as the mock argument matcher
+// [mock.Equal].
+type Assert struct {
+	t      test.Test
+	config *mock.DiffConfig
+}
+
+// New creates a new diff-aware assertion instance for the given test. The
+// diff output can be adjusted using the same diff options as the mock handler,
+// e.g. [mock.MaxDepth], [mock.SortKeys], or [mock.Context].
+func New(t test.Test, opts ...mock.DiffOption) *Assert {
+	return &Assert{t: t, config: mock.NewDiffConfig(opts...)}
+}
+
+// Equal asserts that the actual value is equal to the expected value using
+// the default diff configuration.
+func Equal(t test.Test, want, got any) bool {
+	t.Helper()
+	return New(t).Equal(want, got)
+}
+
+// NotEqual asserts that the actual value is not equal to the unexpected value
+// using the default diff configuration.
+func NotEqual(t test.Test, want, got any) bool {
+	t.Helper()
+	return New(t).NotEqual(want, got)
+}
+
+// Contains asserts that the container contains the given element using the
+// default diff configuration.
+func Contains(t test.Test, container, elem any) bool {
+	t.Helper()
+	return New(t).Contains(container, elem)
+}
+
+// ErrorIs asserts that the error matches the target error via [errors.Is]
+// using the default diff configuration.
+func ErrorIs(t test.Test, err, target error) bool {
+	t.Helper()
+	return New(t).ErrorIs(err, target)
+}
+
+// Eventually asserts that the value provided by the given function becomes
+// equal to the expected value using the default diff configuration.
+func Eventually(
+	t test.Test, want any, got func() any, fncalls ...test.PollFunc,
+) bool {
+	t.Helper()
+	return New(t).Eventually(want, got, fncalls...)
+}
+
+// Equal asserts that the actual value is equal to the expected value. On
+// mismatch, the failure shows the actual and the expected value together with
+// a detailed diff in the same format as a mock argument mismatch.
+func (a *Assert) Equal(want, got any) bool {
+	a.t.Helper()
+
+	if eq := a.config.Equal(want); !eq.Matches(got) {
+		a.t.Errorf("Not equal:\nGot: %s\nWant: %s", eq.Got(got), eq)
+		return false
+	}
+	return true
+}
+
+// NotEqual asserts that the actual value is not equal to the unexpected
+// value.
+func (a *Assert) NotEqual(want, got any) bool {
+	a.t.Helper()
+
+	if eq := a.config.Equal(want); eq.Matches(got) {
+		a.t.Errorf("Should not be equal:\nGot: %s", eq.Got(got))
+		return false
+	}
+	return true
+}
+
+// Contains asserts that the container contains the given element. For
+// strings the element must be a sub-string, for slices and arrays an equal
+// element must exist, and for maps an equal key must exist.
+func (a *Assert) Contains(container, elem any) bool {
+	a.t.Helper()
+
+	eq := a.config.Equal(elem)
+	if found, ok := contains(a.config, container, elem); !ok {
+		a.t.Errorf("Unsupported container:\nGot: %s", eq.Got(container))
+		return false
+	} else if !found {
+		a.t.Errorf("Does not contain:\nGot: %s\nWant: %s",
+			eq.Got(container), eq.Got(elem))
+		return false
+	}
+	return true
+}
+
+// ErrorIs asserts that the error matches the target error via [errors.Is].
+// On mismatch, the failure shows both errors together with a diff of their
+// messages.
+func (a *Assert) ErrorIs(err, target error) bool {
+	a.t.Helper()
+
+	if errors.Is(err, target) {
+		return true
+	}
+
+	diff := ""
+	if err != nil && target != nil {
+		diff = a.config.Diff(target.Error(), err.Error())
+	}
+	if diff != "" {
+		a.t.Errorf("Error mismatch:\nGot: %s\nWant: %s\n"+
+			"Diff (-want, +got):\n%s", errorString(err),
+			errorString(target), diff)
+	} else {
+		a.t.Errorf("Error mismatch:\nGot: %s\nWant: %s",
+			errorString(err), errorString(target))
+	}
+	return false
+}
+
+// Eventually asserts that the value provided by the given function becomes
+// equal to the expected value by polling it via [test.Eventually], that can be
+// configured using [test.Wait] and [test.Tick]. On timeout, the failure shows
+// the last actual value together with a detailed diff.
+func (a *Assert) Eventually(
+	want any, got func() any, fncalls ...test.PollFunc,
+) bool {
+	a.t.Helper()
+
+	return test.Eventually(a.t, func(t test.Test) {
+		(&Assert{t: t, config: a.config}).Equal(want, got())
+	}, fncalls...)
+}
+
+// contains returns whether the container contains the given element and
+// whether the container type is supported.
+func contains(config *mock.DiffConfig, container, elem any) (bool, bool) {
+	value := reflect.ValueOf(container)
+	switch value.Kind() {
+	case reflect.String:
+		str, ok := elem.(string)
+		return ok && strings.Contains(value.String(), str), true
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+			if config.Match(elem, value.Index(index).Interface()) {
+				return true, true
+			}
+		}
+		return false, true
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			if config.Match(elem, key.Interface()) {
+				return true, true
+			}
+		}
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// errorString returns a string representation of the given error containing
+// its type and message.
+func errorString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%T(%q)", err, err.Error())
+}
@@ -0,0 +1,199 @@
+package assert_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/tkrop/go-testing/assert"
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+type Struct struct {
+	Name  string
+	Value int
+}
+
+// diff creates the complete expected diff output in unified diff format.
+func diff(hunk, content string) string {
+	return "--- Want\n+++ Got\n@@ -" + hunk + " @@\n" + content
+}
+
+type AssertParams struct {
+	call         func(t test.Test) bool
+	expect       []any
+	expectResult bool
+}
+
+var assertTestCases = map[string]AssertParams{
+	"equal-match": {
+		call: func(t test.Test) bool {
+			return assert.Equal(t, Struct{Name: "a"}, Struct{Name: "a"})
+		},
+		expectResult: true,
+	},
+	"equal-mismatch": {
+		call: func(t test.Test) bool {
+			return assert.Equal(t, Struct{Name: "a", Value: 1},
+				Struct{Name: "a", Value: 2})
+		},
+		expect: []any{"Not equal:\n" +
+			"Got: assert_test.Struct(assert_test.Struct{Name:\"a\", Value:2})\n" +
+			"Want: assert_test.Struct(assert_test.Struct{Name:\"a\", Value:1})\n" +
+			"Diff (-want, +got):\n" + diff("1,5 +1,5", ""+
+			" (assert_test.Struct) {\n"+
+			"   Name: (string) (len=1) \"a\",\n"+
+			"-  Value: (int) 1\n"+
+			"+  Value: (int) 2\n"+
+			" }\n \n"),
+		},
+	},
+	"equal-mismatch-context": {
+		call: func(t test.Test) bool {
+			return assert.New(t, mock.Context(0)).Equal(
+				Struct{Name: "a", Value: 1}, Struct{Name: "a", Value: 2})
+		},
+		expect: []any{"Not equal:\n" +
+			"Got: assert_test.Struct(assert_test.Struct{Name:\"a\", Value:2})\n" +
+			"Want: assert_test.Struct(assert_test.Struct{Name:\"a\", Value:1})\n" +
+			"Diff (-want, +got):\n" + diff("3 +3", ""+
+			"-  Value: (int) 1\n"+
+			"+  Value: (int) 2\n"),
+		},
+	},
+	"equal-mismatch-type": {
+		call: func(t test.Test) bool {
+			return assert.Equal(t, 1, "1")
+		},
+		expect: []any{"Not equal:\nGot: string(\"1\")\nWant: int(1)"},
+	},
+	"equal-like-mock": {
+		call: func(t test.Test) bool {
+			matcher := mock.NewMocks(t).Equal([]int{1, 2})
+			matcher.Matches([]int{1, 3})
+			recorder := test.NewFailureRecorder(t).Run(func(t test.Test) {
+				assert.Equal(t, []int{1, 2}, []int{1, 3})
+			})
+			return recorder.AssertFailures(t, "Not equal:\n"+
+				"Got: "+matcher.Got([]int{1, 3})+"\n"+
+				"Want: "+matcher.String())
+		},
+		expectResult: true,
+	},
+	"not-equal-match": {
+		call: func(t test.Test) bool {
+			return assert.NotEqual(t, 1, 2)
+		},
+		expectResult: true,
+	},
+	"not-equal-mismatch": {
+		call: func(t test.Test) bool {
+			return assert.NotEqual(t, "a", "a")
+		},
+		expect: []any{"Should not be equal:\nGot: string(\"a\")"},
+	},
+	"contains-string": {
+		call: func(t test.Test) bool {
+			return assert.Contains(t, "alpha", "lph")
+		},
+		expectResult: true,
+	},
+	"contains-slice": {
+		call: func(t test.Test) bool {
+			return assert.Contains(t, []Struct{{Name: "a"}}, Struct{Name: "a"})
+		},
+		expectResult: true,
+	},
+	"contains-map": {
+		call: func(t test.Test) bool {
+			return assert.Contains(t, map[string]int{"a": 1}, "a")
+		},
+		expectResult: true,
+	},
+	"contains-missing": {
+		call: func(t test.Test) bool {
+			return assert.Contains(t, []int{1, 2}, 3)
+		},
+		expect: []any{"Does not contain:\n" +
+			"Got: []int([]int{1, 2})\nWant: int(3)"},
+	},
+	"contains-unsupported": {
+		call: func(t test.Test) bool {
+			return assert.Contains(t, 1, 1)
+		},
+		expect: []any{"Unsupported container:\nGot: int(1)"},
+	},
+	"error-is-match": {
+		call: func(t test.Test) bool {
+			return assert.ErrorIs(t, fmt.Errorf("wrap: %w",
+				fs.ErrNotExist), fs.ErrNotExist)
+		},
+		expectResult: true,
+	},
+	"error-is-nil": {
+		call: func(t test.Test) bool {
+			return assert.ErrorIs(t, nil, fs.ErrNotExist)
+		},
+		expect: []any{"Error mismatch:\nGot: <nil>\n" +
+			"Want: *errors.errorString(\"file does not exist\")"},
+	},
+	"error-is-mismatch": {
+		call: func(t test.Test) bool {
+			return assert.ErrorIs(t, errors.New("file does exist"),
+				fs.ErrNotExist)
+		},
+		expect: []any{"Error mismatch:\n" +
+			"Got: *errors.errorString(\"file does exist\")\n" +
+			"Want: *errors.errorString(\"file does not exist\")\n" +
+			"Diff (-want, +got):\n" + diff("1 +1", ""+
+			"-file does not exist\n"+
+			"+file does exist\n"),
+		},
+	},
+
+	"eventually-match": {
+		call: func(t test.Test) bool {
+			count := 0
+			return assert.Eventually(t, 3, func() any {
+				count++
+				return count
+			}, test.Tick(time.Millisecond))
+		},
+		expectResult: true,
+	},
+	"eventually-mismatch": {
+		call: func(t test.Test) bool {
+			return assert.New(t, mock.Context(0)).Eventually(1,
+				func() any { return 2 }, test.Wait(5*time.Millisecond),
+				test.Tick(time.Millisecond))
+		},
+		expect: []any{regexp.MustCompile(`^eventually failed after ` +
+			`[0-9]+m?s \[attempts: [0-9]+\]:\n\t.*:[0-9]+: ` +
+			`Errorf: Not equal:\n\t\tGot: int\(2\)\n\t\tWant: int\(1\)$`)},
+	},
+}
+
+func TestAssert(t *testing.T) {
+	test.Map(t, assertTestCases).
+		Run(func(t test.Test, param AssertParams) {
+			// Given
+			recorder := test.NewFailureRecorder(t)
+			result := false
+
+			// When
+			recorder.Run(func(t test.Test) {
+				result = param.call(t)
+			})
+
+			// Then
+			if result != param.expectResult {
+				t.Errorf("result mismatch: want %t, got %t",
+					param.expectResult, result)
+			}
+			recorder.AssertFailures(t, param.expect...)
+		})
+}
@@ -0,0 +1,5 @@
+// Package assert contains a small collection of diff-aware assertions that
+// are using the same diff configuration and output format as the matchers of
+// the mock package. It is part of the public interface, however, we are still
+// experimenting to optimize the interface and the user experience.
+package assert
@@ -2,10 +2,12 @@ package gock
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/h2non/gock"
 	"go.uber.org/mock/gomock"
 
+	"github.com/tkrop/go-testing/internal/sync"
 	"github.com/tkrop/go-testing/test"
 )
 
@@ -64,6 +66,9 @@ func NewController(t test.Test) *Controller {
 			ctrl.Cleanup()
 		})
 	}
+	if c, ok := ctrl.t.(sync.Tracker); ok {
+		c.Track(ctrl.Pending)
+	}
 	return ctrl
 }
 
@@ -93,7 +98,7 @@ func (*Controller) RestoreClient(client *http.Client) {
 
 // RoundTrip receives HTTP requests and matches them against the registered
 // HTTP request/response mocks. If a match is found it is used to construct the
-// response, else the request is tracked as unmatched. If networing is enabled,
+// response, else the request is logged as unmatched. If networing is enabled,
 // the original transport is used to handle the request to .
 //
 // This method implements the `http.RoundTripper` interface and is used by
@@ -102,8 +107,10 @@ func (ctrl *Controller) RoundTrip(req *http.Request) (*http.Response, error) {
 	// find matching mock for the incoming request.
 	mock, err := ctrl.MockStore.Match(req)
 	if err != nil {
+		ctrl.t.Logf("request failed [%s %s]: %v", req.Method, req.URL, err)
 		return nil, err
 	} else if mock == nil {
+		ctrl.t.Logf("request unmatched [%s %s]", req.Method, req.URL)
 		return nil, gock.ErrCannotMatch
 	}
 	defer ctrl.MockStore.Clean()
@@ -111,6 +118,20 @@ func (ctrl *Controller) RoundTrip(req *http.Request) (*http.Response, error) {
 	return gock.Responder(req, mock.Response(), nil) //nolint:wrapcheck // transparent wrapper
 }
 
+// Pending returns the sorted string representations of the pending HTTP
+// request/response mocks, i.e. the HTTP requests that are expected but not
+// yet received. This can be used to analyze blocked tests.
+func (ctrl *Controller) Pending() []string {
+	mocks := ctrl.MockStore.Pending()
+	pending := make([]string, 0, len(mocks))
+	for _, mock := range mocks {
+		req := mock.Request()
+		pending = append(pending, req.Method+" "+req.URLStruct.String())
+	}
+	sort.Strings(pending)
+	return pending
+}
+
 // Cleanup checks if all the HTTP request/response mocks that were expected to
 // be called have been called. This function is automatically registered with
 // the test controller and will be called when the test is finished.
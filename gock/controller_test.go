@@ -83,3 +83,39 @@ func TestPanic(t *testing.T) {
 	// Then
 	assert.Fail(t, "did not panic")
 }
+
+type PendingParams struct {
+	paths         []string
+	expectPending []string
+}
+
+var pendingTestCases = map[string]PendingParams{
+	"no-mocks": {
+		expectPending: []string{},
+	},
+	"pending-mocks": {
+		paths: []string{"/baz", "/bar"},
+		expectPending: []string{
+			"GET http://foo.com/bar",
+			"GET http://foo.com/baz",
+		},
+	},
+}
+
+func TestPending(t *testing.T) {
+	test.Map(t, pendingTestCases).
+		Run(func(t test.Test, param PendingParams) {
+			// Given
+			ctrl := gock.NewController(t)
+			for _, path := range param.paths {
+				ctrl.New("http://foo.com").Get(path).Reply(200)
+			}
+
+			// When
+			pending := ctrl.Pending()
+
+			// Then
+			assert.Equal(t, param.expectPending, pending)
+			ctrl.MockStore.Flush()
+		})
+}
@@ -80,6 +80,12 @@ func (b *FileBuilder) Build() *File {
 			b.paramImports(method.Params).
 				paramImports(method.Results)
 		}
+		if mock.Delegate != nil {
+			if _, ok := b.paths[ImportSync.Path]; !ok {
+				b.addImport(ImportSync)
+			}
+			b.paramImports([]*Params{mock.Delegate})
+		}
 	}
 
 	b.buildImports().applyImports()
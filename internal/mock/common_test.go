@@ -2,7 +2,12 @@ package mock_test
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 
@@ -83,11 +88,65 @@ var (
 	targetTestingIFace  = targetTesting.With(nameIFaceMock)
 )
 
+// exportData is the flag whether the package loader supports the export data
+// of the go toolchain. If not supported, e.g. for a newer go toolchain, the
+// test loaders must type check all packages from source.
+var exportData = func() bool {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes,
+	}, "fmt")
+	return err == nil && len(pkgs) == 1 && len(pkgs[0].Errors) == 0
+}()
+
+// sourceMode is the additional load mode to type check all packages from
+// source, if the export data of the go toolchain is not supported.
+const sourceMode = packages.NeedSyntax | packages.NeedImports |
+	packages.NeedDeps
+
+// goRoot is the source directory of the go toolchain.
+var goRoot = func() string {
+	out, _ := exec.Command("go", "env", "GOROOT").Output()
+	return filepath.Join(strings.TrimSpace(string(out)), "src") +
+		string(filepath.Separator)
+}()
+
+// parseFile parses the given source file dropping all function bodies of the
+// standard library to reduce the effort of type checking all packages from
+// source.
+func parseFile(
+	fset *token.FileSet, filename string, src []byte,
+) (*ast.File, error) {
+	file, err := parser.ParseFile(fset, filename, src,
+		parser.AllErrors|parser.ParseComments)
+	if file != nil && strings.HasPrefix(filename, goRoot) {
+		for _, decl := range file.Decls {
+			if fdecl, ok := decl.(*ast.FuncDecl); ok {
+				fdecl.Body = nil
+			}
+		}
+	}
+	return file, err //nolint:wrapcheck // test only
+}
+
+func newLoader(dir string) Loader {
+	loader := NewLoader(dir)
+	if !exportData {
+		loader.(*CachedLoader).Config.Mode |= sourceMode
+		loader.(*CachedLoader).Config.ParseFile = parseFile
+	}
+	return loader
+}
+
 func newPackage(path string) []*packages.Package {
-	pkgs, _ := packages.Load(&packages.Config{
+	config := &packages.Config{
 		Mode:  packages.NeedName | packages.NeedTypes,
 		Tests: true,
-	}, path)
+	}
+	if !exportData {
+		config.Mode |= sourceMode
+		config.ParseFile = parseFile
+	}
+	pkgs, _ := packages.Load(config, path)
 	return pkgs
 }
 
@@ -132,10 +191,10 @@ func methodsMockIFaceFunc(mocktest, test, mock string) []*Method {
 
 var (
 	// Use two different singleton loaders.
-	loaderRoot = NewLoader(DirDefault)
-	loaderMock = NewLoader(DirDefault)
-	loaderTest = NewLoader(dirSubTest)
-	loaderFail = NewLoader(dirUnknown)
+	loaderRoot = newLoader(DirDefault)
+	loaderMock = newLoader(DirDefault)
+	loaderTest = newLoader(dirSubTest)
+	loaderFail = newLoader(dirUnknown)
 
 	// Use singleton template for testing.
 	template = MustTemplate()
@@ -148,6 +207,9 @@ var (
 		Path: pathTest, File: getMethod(pkgParsedMock, iface),
 		Package: pkgTest, Name: iface,
 	}
+	delegateIFaceAny = &Params{
+		Name: "delegate", Type: pathTest + "." + iface,
+	}
 	sourceGoMockTestReporter = &Type{
 		Path: pathGoMock, Package: pkgGoMock,
 		File: getMethod(pkgParsedGoMock, "TestReporter"),
@@ -30,9 +30,15 @@ type Generator struct {
 // NewGenerator creates a new mock generator with given default context
 // directory and default target setup.
 func NewGenerator(dir string, target *Type) *Generator {
+	return NewGeneratorWithLoader(NewLoader(dir), target)
+}
+
+// NewGeneratorWithLoader creates a new mock generator with given package
+// loader and default target setup.
+func NewGeneratorWithLoader(loader Loader, target *Type) *Generator {
 	tempplate, imports, _ := NewTemplate()
 	return &Generator{
-		parser:   NewParser(NewLoader(dir), target),
+		parser:   NewParser(loader, target),
 		template: tempplate,
 		imports:  imports,
 	}
@@ -69,7 +69,7 @@ var generateTestCases = map[string]GenerateParams{
 }
 
 func TestGenerate(t *testing.T) {
-	gen := NewGenerator(DirDefault, TargetDefault)
+	gen := NewGeneratorWithLoader(newLoader(DirDefault), TargetDefault)
 	test.Map(t, generateTestCases).
 		Run(func(t test.Test, param GenerateParams) {
 			// Given
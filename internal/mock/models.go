@@ -234,6 +234,9 @@ type Mock struct {
 	Target *Type
 	// Methods of source/target interface.
 	Methods []*Method
+	// Delegate provides the delegate field of the source interface type, if
+	// the mock is forwarding unexpected calls to a real implementation.
+	Delegate *Params
 }
 
 // Import information.
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"go/token"
+	"strconv"
 	"strings"
 )
 
@@ -45,6 +46,10 @@ const (
 	// Source/target interface mapping argument type. Must be a list of
 	// identifier mappings.
 	argTypeIFace
+
+	// Delegate argument type. Must be a boolean value enabling or disabling
+	// mocks forwarding unexpected calls to a real implementation.
+	argTypeDelegate
 )
 
 // Parser is a mock setup command line argument parser.
@@ -69,6 +74,8 @@ type ParseState struct {
 	source *Type
 	// Target provides the actual target interface setup.
 	target *Type
+	// Delegate provides the actual delegate setup.
+	delegate bool
 }
 
 // NewParser creates a new mock setup command line argument parser with loading
@@ -103,6 +110,13 @@ func (parser *Parser) Parse(args ...string) ([]*Mock, []error) {
 			for _, arg := range strings.Split(arg, ",") {
 				state.creatMocks(pos, arg)
 			}
+		case argTypeDelegate:
+			delegate, err := strconv.ParseBool(arg)
+			if err != nil {
+				err := NewErrArgInvalid(pos, rarg)
+				state.errs = append(state.errs, err)
+			}
+			state.delegate = delegate
 		case argTypeNotFound:
 			err := NewErrArgNotFound(pos, arg)
 			state.errs = append(state.errs, err)
@@ -125,7 +139,9 @@ func (parser *Parser) Parse(args ...string) ([]*Mock, []error) {
 // value and returns the argument type with the remaining argument value.
 func (parser *Parser) argType(arg string) (argType, string) {
 	if strings.Index(arg, "--") == 0 {
-		if !strings.Contains(arg, "=") {
+		if arg == "--delegate" {
+			return argTypeDelegate, "true"
+		} else if !strings.Contains(arg, "=") {
 			return argTypeUnknown, arg
 		}
 		return parser.argTypeParse(arg)
@@ -161,6 +177,8 @@ func (parser *Parser) argTypeParse(arg string) (argType, string) {
 
 	case "iface":
 		return argTypeIFace, sarg
+	case "delegate":
+		return argTypeDelegate, sarg
 	default:
 		return argTypeUnknown, arg
 	}
@@ -318,6 +336,12 @@ func (state *ParseState) creatMocks(pos int, arg string) {
 				Target:  &target,
 				Methods: iface.Methods,
 			}
+			if state.delegate {
+				mock.Delegate = &Params{
+					Name: "delegate",
+					Type: iface.Source.Path + "." + iface.Source.Name,
+				}
+			}
 			state.targets[target] = mock
 			state.mocks = append(state.mocks, mock)
 		}
@@ -107,7 +107,7 @@ var parseTestCases = map[string]ParseParams{
 		}},
 	},
 	"source-package-invalid": {
-		loader: NewLoader(DirDefault), // ensure path is not preload.
+		loader: newLoader(DirDefault), // ensure path is not preload.
 		args:   []string{pathUnknown},
 		expectError: []error{
 			NewErrArgFailure(0, ".",
@@ -470,6 +470,41 @@ var parseTestCases = map[string]ParseParams{
 		}},
 	},
 
+	"delegate-flag": {
+		loader: loaderTest,
+		args:   []string{"--delegate", iface},
+		expectMocks: []*Mock{{
+			Source:   sourceIFaceAny,
+			Target:   targetTestTestIFace,
+			Methods:  methodsLoadIFace,
+			Delegate: delegateIFaceAny,
+		}},
+	},
+	"delegate-explicit": {
+		loader: loaderTest,
+		args:   []string{"--delegate=true", iface},
+		expectMocks: []*Mock{{
+			Source:   sourceIFaceAny,
+			Target:   targetTestTestIFace,
+			Methods:  methodsLoadIFace,
+			Delegate: delegateIFaceAny,
+		}},
+	},
+	"delegate-disabled": {
+		loader: loaderTest,
+		args:   []string{"--delegate", "--delegate=false", iface},
+		expectMocks: []*Mock{{
+			Source:  sourceIFaceAny,
+			Target:  targetTestTestIFace,
+			Methods: methodsLoadIFace,
+		}},
+	},
+	"delegate-invalid": {
+		loader:      loaderTest,
+		args:        []string{"--delegate=any", iface},
+		expectError: []error{NewErrArgInvalid(0, "--delegate=any")},
+	},
+
 	"iface-failure-parsing-regexp": {
 		loader: loaderTest,
 		args: []string{
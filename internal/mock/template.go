@@ -20,6 +20,9 @@ var (
 	ImportsTemplate = []*Import{
 		ImportReflect, ImportGomock, ImportMock,
 	}
+	ImportSync = &Import{
+		Alias: "sync", Path: "sync",
+	}
 
 	MockFileFuncMap = template.FuncMap{
 		"ImportsList": importArgs,
@@ -46,6 +49,10 @@ import (
 type {{$mock.Target.Name}} struct {
 	ctrl     *gomock.Controller
 	recorder *{{$mock.Target.Name}}Recorder
+{{- if $mock.Delegate}}
+	mutex    sync.Mutex
+	delegate {{$mock.Delegate.Type}}
+{{- end}}
 }
 
 // {{$mock.Target.Name}}Recorder is the mock recorder for {{$mock.Target.Name}}.
@@ -64,6 +71,30 @@ func New{{$mock.Target.Name}}(ctrl *gomock.Controller) *{{$mock.Target.Name}} {
 func (m *{{$mock.Target.Name}}) EXPECT() *{{$mock.Target.Name}}Recorder {
 	return m.recorder
 }
+{{- if $mock.Delegate}}
+
+// DELEGATE sets up the real implementation to forward all calls to, that are
+// not matching a pending expected call.
+func (m *{{$mock.Target.Name}}) DELEGATE(delegate {{$mock.Delegate.Type}}) *{{$mock.Target.Name}} {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.delegate == nil {
+		mock.Delegate(m.ctrl, m, m.target)
+	}
+	m.delegate = delegate
+	return m
+}
+
+// target returns the real implementation to forward calls to.
+func (m *{{$mock.Target.Name}}) target() any {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.delegate == nil {
+		return nil
+	}
+	return m.delegate
+}
+{{- end}}
 
 {{- if not .Methods}}
 
@@ -18,6 +18,9 @@ var (
 	expectIFaceStub = string(test.Must(os.ReadFile("mock_stub_test.gox")))
 	// Generated IFace mock with methods.
 	expectIFace = string(test.Must(os.ReadFile("mock_iface_test.gox")))
+	// Generated IFace mock with methods delegating to real implementation.
+	expectIFaceDelegate = string(test.Must(
+		os.ReadFile("mock_delegate_test.gox")))
 )
 
 type TemplateParams struct {
@@ -41,6 +44,15 @@ var templateTestCases = map[string]TemplateParams{
 		}},
 		expect: expectIFace,
 	},
+	"iface-with-delegate": {
+		mocks: []*Mock{{
+			Source:   sourceIFaceAny,
+			Target:   targetMockTestIFace.With(&Type{File: "-"}),
+			Methods:  methodsLoadIFace,
+			Delegate: delegateIFaceAny,
+		}},
+		expect: expectIFaceDelegate,
+	},
 }
 
 func TestTemplate(t *testing.T) {
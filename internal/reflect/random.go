@@ -3,6 +3,10 @@ package reflect
 import (
 	"math/rand"
 	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -15,7 +19,10 @@ type random struct {
 
 // Random is an interface for generating random data structures.
 type Random interface {
+	// Random generates random data into the given data structure.
 	Random(obj any) any
+	// Mutate changes all reachable primitive values in place.
+	Mutate(obj any)
 }
 
 // NewRandom creates a random generator with default size and length limits.
@@ -62,6 +69,117 @@ func (r *random) Random(obj any) any {
 	}
 }
 
+// Mutate changes all primitive values reachable from the given pointer in
+// place to new random values that differ from the current values. Pointers,
+// slices, and maps are not replaced but followed, so that all values shared
+// via references are changed as well. Opaque structs of the standard library,
+// e.g. `sync.Mutex` or `time.Location`, are left unchanged, while `time.Time`
+// values are moved forward.
+func (r *random) Mutate(obj any) {
+	r.mutate(reflect.ValueOf(obj), map[uintptr]bool{})
+}
+
+// mutate changes all primitive values reachable from the given value in
+// place, skipping already visited pointers to support cyclic structures.
+func (r *random) mutate(v reflect.Value, visited map[uintptr]bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || visited[v.Pointer()] {
+			return
+		}
+		visited[v.Pointer()] = true
+		r.mutate(v.Elem(), visited)
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		r.mutate(elem, visited)
+		r.setField(v, elem)
+	case reflect.Struct:
+		if v.Type() == reflect.TypeFor[time.Time]() {
+			r.setField(v, reflect.ValueOf(v.Interface().(time.Time).
+				Add(time.Duration(r.rand.Intn(2<<r.length)+1)*time.Second)))
+			return
+		} else if isOpaque(v.Type()) {
+			return
+		}
+		for i := range v.NumField() {
+			field := v.Field(i)
+			if !v.Type().Field(i).IsExported() {
+				field = FieldValueOf(v, i)
+			}
+			r.mutate(field, visited)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			r.mutate(v.Index(i), visited)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			r.mutate(elem, visited)
+			v.SetMapIndex(key, elem)
+		}
+	default:
+		r.mutatePrimitive(v)
+	}
+}
+
+// mutatePrimitive changes the given primitive value to a new random value
+// that differs from the current value.
+func (r *random) mutatePrimitive(v reflect.Value) {
+	if !isPrimitiveKind(v.Kind()) {
+		return
+	} else if v.Kind() == reflect.Bool {
+		r.setField(v, reflect.ValueOf(!v.Bool()).Convert(v.Type()))
+		return
+	}
+
+	current := v.Interface()
+	for range 16 {
+		value := reflect.ValueOf(r.newPrimitive(v.Kind())).Convert(v.Type())
+		if value.Interface() != current {
+			r.setField(v, value)
+			return
+		}
+	}
+}
+
+// modules provides the paths of the main module and all its dependencies.
+var modules = sync.OnceValue(func() []string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	paths := []string{info.Main.Path}
+	for _, dep := range info.Deps {
+		paths = append(paths, dep.Path)
+	}
+	return paths
+})
+
+// isOpaque checks if the given struct type is defined in the standard library
+// and thus must be treated as opaque, since its internal state must not be
+// changed arbitrarily, e.g. `sync.Mutex` or `time.Location`.
+func isOpaque(t reflect.Type) bool {
+	path := t.PkgPath()
+	if path == "" || path == "main" {
+		return false
+	}
+	for _, module := range modules() {
+		if module != "" && (path == module ||
+			strings.HasPrefix(path, module+"/")) {
+			return false
+		}
+	}
+	elem, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(elem, ".")
+}
+
 // isPrimitiveKind checks if a reflect.Kind is a primitive type.
 func isPrimitiveKind(k reflect.Kind) bool {
 	switch k {
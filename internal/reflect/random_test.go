@@ -2,11 +2,15 @@ package reflect_test
 
 import (
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	. "github.com/tkrop/go-testing/internal/reflect"
+	"github.com/tkrop/go-testing/mock"
 	"github.com/tkrop/go-testing/test"
 )
 
@@ -287,3 +291,74 @@ func TestRandom(t *testing.T) {
 			p.check(t, result)
 		})
 }
+
+//lint:ignore U1000 // needed by reflection.
+type Mutable struct {
+	Value int
+	Ptr   *int
+	ptr   *int
+	Time  time.Time
+	Loc   *time.Location
+	Mutex *sync.Mutex
+	Any   any
+}
+
+type MutateParams struct {
+	value       func() any
+	expectPaths []string
+}
+
+var mutateTestCases = map[string]MutateParams{
+	"nil-value": {
+		value: func() any { return nil },
+	},
+	"nil-pointer": {
+		value: func() any { return (*Simple)(nil) },
+	},
+	"primitive-pointer": {
+		value:       func() any { value := 1; return &value },
+		expectPaths: []string{"."},
+	},
+	"bool-pointer": {
+		value:       func() any { value := true; return &value },
+		expectPaths: []string{"."},
+	},
+	"struct-pointer": {
+		value: func() any { return NewRandom(42, 5, 20).Random(&Simple{}) },
+		expectPaths: []string{
+			".a", ".b", ".c", ".d", ".e", ".f", ".g",
+			".h", ".i", ".j", ".k", ".l", ".m", ".n",
+		},
+	},
+	"struct-references": {
+		value: func() any {
+			value, ptr, other := 1, 2, 3
+			return &Mutable{
+				Value: 0, Ptr: &value, ptr: &ptr,
+				Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Loc:  time.UTC, Mutex: &sync.Mutex{}, Any: &other,
+			}
+		},
+		expectPaths: []string{".Value", ".Ptr", ".ptr", ".Time", ".Any"},
+	},
+}
+
+func TestMutate(t *testing.T) {
+	test.Map(t, mutateTestCases).
+		Run(func(t test.Test, p MutateParams) {
+			// Given
+			value, expect := p.value(), p.value()
+			rand := NewRandom(42, 5, 20)
+
+			// When
+			rand.Mutate(value)
+
+			// Then
+			paths := []string{}
+			for _, path := range mock.NewDiffConfig().Paths(expect, value) {
+				path, _, _ = strings.Cut(path, ": want")
+				paths = append(paths, path)
+			}
+			assert.ElementsMatch(t, p.expectPaths, paths)
+		})
+}
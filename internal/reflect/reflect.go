@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"unsafe"
 )
 
 // Aliases for types.
@@ -305,3 +306,38 @@ func MakeFuncOf(
 
 	return mvalue.Interface()
 }
+
+// FieldOf returns the value of the struct field with the given index of the
+// given struct value. In contrast to [reflect.Value.Field], the value is also
+// returned for unexported fields.
+func FieldOf(v reflect.Value, index int) any {
+	return FieldValueOf(v, index).Interface()
+}
+
+// FieldByName returns the value of the struct field with the given name of
+// the given struct value, or `nil` if the field does not exist. In contrast to
+// [reflect.Value.FieldByName], the value is also returned for unexported
+// fields.
+func FieldByName(v reflect.Value, name string) any {
+	if field, ok := v.Type().FieldByName(name); ok && len(field.Index) == 1 {
+		return FieldOf(v, field.Index[0])
+	}
+	return nil
+}
+
+// FieldValueOf returns the reflective value of the struct field with the given
+// index of the given struct value. In contrast to [reflect.Value.Field], the
+// value of unexported fields can also be accessed via
+// [reflect.Value.Interface].
+func FieldValueOf(v reflect.Value, index int) reflect.Value {
+	if !v.CanAddr() {
+		addr := reflect.New(v.Type()).Elem()
+		addr.Set(v)
+		v = addr
+	}
+
+	field := v.Field(index)
+	// #nosec G103 -- This is a safe use of unsafe.Pointer.
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).
+		Elem()
+}
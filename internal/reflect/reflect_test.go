@@ -666,3 +666,79 @@ func TestMakeFuncOf(t *testing.T) {
 			assert.Equal(t, param.result, result)
 		})
 }
+
+type FieldOfParams struct {
+	value  any
+	index  int
+	expect any
+}
+
+var fieldOfTestCases = map[string]FieldOfParams{
+	"exported": {
+		value:  ExportParam{Value: "value"},
+		index:  0,
+		expect: "value",
+	},
+	"unexported": {
+		value:  struct{ value int }{value: 1},
+		index:  0,
+		expect: 1,
+	},
+	"addressable": {
+		value:  &struct{ value string }{value: "value"},
+		index:  0,
+		expect: "value",
+	},
+}
+
+func TestFieldOf(t *testing.T) {
+	test.Map(t, fieldOfTestCases).
+		Run(func(t test.Test, param FieldOfParams) {
+			// Given
+			value := reflect.ValueOf(param.value)
+			if value.Kind() == reflect.Ptr {
+				value = value.Elem()
+			}
+
+			// When
+			field := reflect.FieldOf(value, param.index)
+
+			// Then
+			assert.Equal(t, param.expect, field)
+		})
+}
+
+type FieldByNameParams struct {
+	value  any
+	name   string
+	expect any
+}
+
+var fieldByNameTestCases = map[string]FieldByNameParams{
+	"exported": {
+		value:  ExportParam{Value: "value"},
+		name:   "Value",
+		expect: "value",
+	},
+	"unexported": {
+		value:  struct{ value int }{value: 1},
+		name:   "value",
+		expect: 1,
+	},
+	"missing": {
+		value: struct{ value int }{value: 1},
+		name:  "other",
+	},
+}
+
+func TestFieldByName(t *testing.T) {
+	test.Map(t, fieldByNameTestCases).
+		Run(func(t test.Test, param FieldByNameParams) {
+			// When
+			field := reflect.FieldByName(
+				reflect.ValueOf(param.value), param.name)
+
+			// Then
+			assert.Equal(t, param.expect, field)
+		})
+}
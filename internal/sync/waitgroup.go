@@ -20,6 +20,12 @@ type Synchronizer interface {
 	WaitGroup(wg WaitGroup)
 }
 
+// Tracker is an interface to track the pending expectations of a component,
+// e.g. a mock controller, to report them in case of a blocked test.
+type Tracker interface {
+	Track(pending func() []string)
+}
+
 // NewWaitGroup creates a new standard wait group.
 func NewWaitGroup() WaitGroup {
 	return &sync.WaitGroup{}
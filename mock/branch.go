@@ -0,0 +1,325 @@
+package mock
+
+import (
+	gosync "sync"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/tkrop/go-testing/internal/reflect"
+)
+
+// branches is the decision state of a set of alternative mock call setups, of
+// which at most one is taken on the first call of any of its mock calls.
+type branches struct {
+	// Mutex to synchronize concurrent calls.
+	mutex gosync.Mutex
+	// Mock handler the branches are set up for.
+	mocks *Mocks
+	// Flag whether exactly one of the branches must be taken.
+	required bool
+	// Index of the taken branch, or -1 if no branch is taken yet.
+	taken int
+	// Mock calls set up per branch.
+	calls [][]*Call
+	// Minimum number of mock calls per mock call and branch.
+	mins [][]int
+	// Expected mock calls registered on the wait group per branch.
+	counts []int
+}
+
+// decisions is the set of required alternative mock call setups of a mock
+// handler, that are checked for a taken branch on waiting for the mock calls.
+type decisions struct {
+	// Mutex to synchronize concurrent access.
+	mutex gosync.Mutex
+	// Required alternative mock call setups.
+	branches []*branches
+}
+
+// branch is the wait group of a single branch that is collecting the expected
+// mock calls until the branch is taken and then forwards them to the wait
+// group of the mock handler.
+type branch struct {
+	// Branches the branch belongs to.
+	branches *branches
+	// Index of the branch.
+	index int
+}
+
+// OneOf creates a set of alternative mock call setups, of which exactly one
+// must be executed, e.g. to model the hit and miss paths of a cache. The
+// branch is chosen on the first call of any of its mock calls, i.e. branches
+// must be distinguishable by their first mock call. Afterwards, the mock calls
+// of all other branches are removed from the expected mock calls. The mock
+// calls of each branch are ordered after the preceding mock calls, while the
+// succeeding mock calls are ordered after the last mock calls of all branches.
+//
+// The wait group only waits for the mock calls of the taken branch, i.e.
+// [Mocks.Wait] is not blocked by the branches that are not taken. If no branch
+// is taken when all other mock calls are consumed, [Mocks.Wait] reports a
+// [ErrBranchNotTaken] failure instead of blocking. Thus, the branch must be
+// taken before the succeeding mock calls or before waiting.
+func OneOf(fncalls ...func(*Mocks) any) func(*Mocks) any {
+	return func(mocks *Mocks) any {
+		if len(fncalls) == 0 {
+			return nil
+		}
+
+		branches := newBranches(mocks, true, len(fncalls))
+		mocks.decisions.add(branches)
+
+		calls := make([]parallel, 0, len(fncalls))
+		for _, call := range branches.setup(fncalls...) {
+			calls = append(calls, parallel(call))
+		}
+		return calls
+	}
+}
+
+// Optional creates a chain of mock calls that may be executed zero or one
+// times. The chain is taken on the first call of any of its mock calls, which
+// makes the remaining mock calls of the chain required. If the chain is not
+// taken, the succeeding mock calls are still ordered after the preceding mock
+// calls and [Mocks.Wait] is not blocked by the mock calls of the chain.
+func Optional(fncalls ...func(*Mocks) any) func(*Mocks) any {
+	return func(mocks *Mocks) any {
+		return []optional{newBranches(mocks, false, 1).
+			setup(Chain(fncalls...))[0]}
+	}
+}
+
+// Repeat creates a chain of mock calls that repeats the given mock call setup
+// the given number of times, e.g. to model retry loops. Every repetition
+// creates new mock calls, so that the chain is validated by `gomock` in the
+// same way as if the mock call setup was listed multiple times in a [Chain].
+// If the number of times is not positive, no mock calls are set up.
+func Repeat(times int, fncalls ...func(*Mocks) any) func(*Mocks) any {
+	return func(mocks *Mocks) any {
+		if times <= 0 {
+			return nil
+		}
+
+		calls := make([]chain, 0, times*len(fncalls))
+		for range times {
+			for _, fncall := range fncalls {
+				calls = chainCalls(calls, fncall(mocks))
+			}
+		}
+		return calls
+	}
+}
+
+// newBranches creates a new decision state for the given number of branches.
+func newBranches(mocks *Mocks, required bool, size int) *branches {
+	return &branches{
+		mocks:    mocks,
+		required: required,
+		taken:    -1,
+		calls:    make([][]*Call, size),
+		mins:     make([][]int, size),
+		counts:   make([]int, size),
+	}
+}
+
+// setup sets up the given mock call setups as branches using an isolated wait
+// group per branch and returns the mock calls of each branch. The mock calls
+// are prepared to take the branch on their first call. For optional branches
+// the mock calls are set up to be not required until the branch is taken.
+func (b *branches) setup(fncalls ...func(*Mocks) any) []any {
+	results := make([]any, 0, len(fncalls))
+	for index, fncall := range fncalls {
+		mocks := *b.mocks
+		mocks.wg = &branch{branches: b, index: index}
+
+		result := fncall(&mocks)
+		for _, call := range callsOf(result) {
+			b.calls[index] = append(b.calls[index], call)
+			b.mins[index] = append(b.mins[index], minCallsOf(call))
+			if !b.required {
+				setMinCalls(call, 0)
+			}
+			onCall(call, func() { b.call(index) })
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// call takes the branch with the given index on the first call of any of its
+// mock calls and reports an error, if another branch was taken concurrently.
+func (b *branches) call(index int) {
+	if taken, ok := b.take(index); !ok {
+		b.mocks.Ctrl.T.Errorf("%v", NewErrBranchNotTaken(index, taken))
+	}
+}
+
+// take takes the branch with the given index, if no branch was taken yet. On
+// taking the branch, the expected mock calls of the branch are forwarded to
+// the wait group of the mock handler. For required branches the mock calls of
+// all other branches are removed from the expected mock calls, while for
+// optional branches the original minimum number of calls is restored. The
+// method returns the index of the taken branch and whether it is the branch
+// with the given index.
+func (b *branches) take(index int) (int, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.taken >= 0 {
+		return b.taken, b.taken == index
+	}
+	b.taken = index
+
+	b.mocks.withCallSet(func(expected, exhausted reflect.Value) {
+		for bindex, calls := range b.calls {
+			for cindex, call := range calls {
+				if !b.required {
+					setMinCalls(call, b.mins[bindex][cindex])
+				} else if bindex != index {
+					setMinCalls(call, 0)
+					exhaustCall(expected, exhausted, call)
+				}
+			}
+		}
+	})
+
+	b.mocks.wg.Add(b.counts[index])
+	return index, true
+}
+
+// pending returns whether no branch is taken yet.
+func (b *branches) pending() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.taken < 0
+}
+
+// newDecisions creates a new empty set of required alternative mock call
+// setups.
+func newDecisions() *decisions {
+	return &decisions{}
+}
+
+// add adds the given required alternative mock call setups.
+func (d *decisions) add(b *branches) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.branches = append(d.branches, b)
+}
+
+// check reports a failure to the given test reporter for each required
+// alternative mock call setup without a taken branch.
+func (d *decisions) check(t gomock.TestReporter) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, b := range d.branches {
+		if b.pending() {
+			t.Errorf("%v", NewErrBranchNotTaken(-1, -1))
+		}
+	}
+}
+
+// Add adds the given delta of expected mock calls to the branch. The delta is
+// collected until the branch is taken and forwarded afterwards.
+func (wg *branch) Add(delta int) {
+	b := wg.branches
+	b.mutex.Lock()
+	switch b.taken {
+	case -1:
+		b.counts[wg.index] += delta
+		b.mutex.Unlock()
+	case wg.index:
+		b.mutex.Unlock()
+		b.mocks.wg.Add(delta)
+	default:
+		b.mutex.Unlock()
+	}
+}
+
+// Done notifies a consumed mock call of the branch taking the branch, if no
+// branch was taken yet.
+func (wg *branch) Done() {
+	if _, ok := wg.branches.take(wg.index); ok {
+		wg.branches.mocks.wg.Done()
+	}
+}
+
+// Wait waits for the wait group of the mock handler.
+func (wg *branch) Wait() {
+	wg.branches.mocks.wg.Wait()
+}
+
+// callsOf returns the flat list of mock calls of the given mock call tree.
+func callsOf(call any) []*Call {
+	switch call := call.(type) {
+	case *Call:
+		return []*Call{call}
+	case []chain:
+		return callsOfSlice(call)
+	case []parallel:
+		return callsOfSlice(call)
+	case []optional:
+		return callsOfSlice(call)
+	case []detachBoth:
+		return callsOfSlice(call)
+	case []detachHead:
+		return callsOfSlice(call)
+	case []detachTail:
+		return callsOfSlice(call)
+	case nil:
+		return nil
+	default:
+		panic(NewErrNoCall(call))
+	}
+}
+
+// callsOfSlice returns the flat list of mock calls of the given mock call
+// slice.
+func callsOfSlice[T any](calls []T) []*Call {
+	flat := []*Call{}
+	for _, call := range calls {
+		flat = append(flat, callsOf(call)...)
+	}
+	return flat
+}
+
+// minCallsOf returns the minimum number of calls of the given mock call. If
+// the minimum number of calls is not accessible, zero is returned.
+func minCallsOf(call *Call) int {
+	calls, _ := reflect.FieldByName(
+		reflect.ValueOf(call).Elem(), "minCalls").(int)
+	return calls
+}
+
+// setMinCalls sets the minimum number of calls of the given mock call without
+// changing the maximum number of calls as [gomock.Call.MinTimes] does.
+func setMinCalls(call *Call, calls int) {
+	value := reflect.ValueOf(call).Elem()
+	if field, ok := value.Type().FieldByName("minCalls"); ok {
+		reflect.FieldValueOf(value, field.Index[0]).SetInt(int64(calls))
+	}
+}
+
+// exhaustCall moves the given mock call from the expected to the exhausted
+// mock calls, so that any further call is reported as unexpected.
+func exhaustCall(expected, exhausted reflect.Value, call *Call) {
+	for _, key := range expected.MapKeys() {
+		calls := expected.MapIndex(key).Interface().([]*Call)
+		for index, ecall := range calls {
+			if ecall != call {
+				continue
+			}
+
+			expected.SetMapIndex(key, reflect.ValueOf(
+				append(calls[:index:index], calls[index+1:]...)))
+			ecalls := []*Call{}
+			if value := exhausted.MapIndex(key); value.IsValid() {
+				ecalls = value.Interface().([]*Call)
+			}
+			exhausted.SetMapIndex(key, reflect.ValueOf(append(ecalls, call)))
+			return
+		}
+	}
+}
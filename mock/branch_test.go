@@ -0,0 +1,257 @@
+package mock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+type BranchParams struct {
+	setup  mock.SetupFunc
+	call   func(test.Test, *mock.Mocks)
+	expect test.Expect
+}
+
+// oneOfSetup sets up a chain with alternative branches in the middle.
+var oneOfSetup = mock.Chain(
+	CallA("a"),
+	mock.OneOf(
+		CallA("b"),
+		mock.Chain(CallB("c", "d"), CallA("e")),
+	),
+	CallA("f"),
+)
+
+// optionalSetup sets up a chain with an optional chain in the middle.
+var optionalSetup = mock.Chain(
+	CallA("a"),
+	mock.Optional(CallA("b"), CallA("c")),
+	CallA("d"),
+)
+
+// callA creates a test function calling `CallA` for all given inputs.
+func callA(inputs ...string) func(test.Test, *mock.Mocks) {
+	return func(_ test.Test, mocks *mock.Mocks) {
+		for _, input := range inputs {
+			mock.Get(mocks, NewMockIFace[string]).CallA(input)
+		}
+	}
+}
+
+var branchTestCases = map[string]BranchParams{
+	"one-of-first-branch": {
+		setup:  oneOfSetup,
+		call:   callA("a", "b", "f"),
+		expect: test.Success,
+	},
+	"one-of-second-branch": {
+		setup: oneOfSetup,
+		call: func(t test.Test, mocks *mock.Mocks) {
+			iface := mock.Get(mocks, NewMockIFace[string])
+			iface.CallA("a")
+			assert.Equal(t, "d", iface.CallB("c"))
+			iface.CallA("e")
+			iface.CallA("f")
+		},
+		expect: test.Success,
+	},
+	"one-of-both-branches": {
+		setup: oneOfSetup,
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			iface := mock.Get(mocks, NewMockIFace[string])
+			iface.CallA("a")
+			iface.CallA("b")
+			iface.CallB("c")
+		},
+		expect: test.Failure,
+	},
+	"one-of-no-branch": {
+		setup:  oneOfSetup,
+		call:   callA("a", "f"),
+		expect: test.Failure,
+	},
+	"one-of-missing-call": {
+		setup: oneOfSetup,
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			iface := mock.Get(mocks, NewMockIFace[string])
+			iface.CallA("a")
+			iface.CallB("c")
+			iface.CallA("f")
+		},
+		expect: test.Failure,
+	},
+	"one-of-no-calls": {
+		setup:  mock.OneOf(),
+		call:   callA(),
+		expect: test.Success,
+	},
+	"one-of-optional-branch": {
+		setup: mock.Chain(
+			mock.OneOf(
+				mock.Optional(CallA("a")),
+				CallA("b"),
+			),
+			CallA("c"),
+		),
+		call:   callA("a", "c"),
+		expect: test.Success,
+	},
+
+	"optional-taken": {
+		setup:  optionalSetup,
+		call:   callA("a", "b", "c", "d"),
+		expect: test.Success,
+	},
+	"optional-skipped": {
+		setup:  optionalSetup,
+		call:   callA("a", "d"),
+		expect: test.Success,
+	},
+	"optional-skipped-at-end": {
+		setup:  mock.Chain(CallA("a"), mock.Optional(CallA("b"))),
+		call:   callA("a"),
+		expect: test.Success,
+	},
+	"optional-skipped-preceding-call": {
+		setup:  optionalSetup,
+		call:   callA("d"),
+		expect: test.Failure,
+	},
+	"optional-partially-taken": {
+		setup:  optionalSetup,
+		call:   callA("a", "b", "d"),
+		expect: test.Failure,
+	},
+	"optional-taken-after-succeeding-call": {
+		setup:  optionalSetup,
+		call:   callA("a", "d", "b"),
+		expect: test.Failure,
+	},
+	"optional-taken-twice": {
+		setup:  optionalSetup,
+		call:   callA("a", "b", "c", "b", "c", "d"),
+		expect: test.Failure,
+	},
+
+	"repeat-calls": {
+		setup:  mock.Repeat(3, CallA("a")),
+		call:   callA("a", "a", "a"),
+		expect: test.Success,
+	},
+	"repeat-chain": {
+		setup:  mock.Repeat(2, CallA("a"), CallA("b")),
+		call:   callA("a", "b", "a", "b"),
+		expect: test.Success,
+	},
+	"repeat-chain-wrong-order": {
+		setup:  mock.Repeat(2, CallA("a"), CallA("b")),
+		call:   callA("a", "a", "b", "b"),
+		expect: test.Failure,
+	},
+	"repeat-missing-calls": {
+		setup:  mock.Repeat(3, CallA("a")),
+		call:   callA("a", "a"),
+		expect: test.Failure,
+	},
+	"repeat-more-calls": {
+		setup:  mock.Repeat(2, CallA("a")),
+		call:   callA("a", "a", "a"),
+		expect: test.Failure,
+	},
+	"repeat-zero-times": {
+		setup:  mock.Chain(mock.Repeat(0, CallA("a")), CallA("b")),
+		call:   callA("b"),
+		expect: test.Success,
+	},
+	"repeat-zero-times-unexpected-call": {
+		setup:  mock.Repeat(0, CallA("a")),
+		call:   callA("a"),
+		expect: test.Failure,
+	},
+	"repeat-negative-times": {
+		setup:  mock.Chain(mock.Repeat(-1, CallA("a")), CallA("b")),
+		call:   callA("b"),
+		expect: test.Success,
+	},
+	"repeat-negative-times-unexpected-call": {
+		setup:  mock.Repeat(-1, CallA("a")),
+		call:   callA("a"),
+		expect: test.Failure,
+	},
+	"repeat-retry-loop": {
+		setup: mock.Chain(
+			mock.Repeat(2, CallB("a", "failure")),
+			CallB("a", "success"),
+		),
+		call: func(t test.Test, mocks *mock.Mocks) {
+			iface := mock.Get(mocks, NewMockIFace[string])
+			assert.Equal(t, "failure", iface.CallB("a"))
+			assert.Equal(t, "failure", iface.CallB("a"))
+			assert.Equal(t, "success", iface.CallB("a"))
+		},
+		expect: test.Success,
+	},
+}
+
+func TestBranch(t *testing.T) {
+	test.Map(t, branchTestCases).
+		Run(func(t test.Test, param BranchParams) {
+			// Given
+			mocks := mock.NewMocks(t).Expect(param.setup)
+
+			// When
+			param.call(t, mocks)
+
+			// Then
+			if param.expect == test.Success {
+				mocks.Wait()
+			}
+		})
+}
+
+type OneOfWaitParams struct {
+	setup          mock.SetupFunc
+	call           func(test.Test, *mock.Mocks)
+	expectFailures []string
+}
+
+var oneOfWaitTestCases = map[string]OneOfWaitParams{
+	"one-of-no-branch": {
+		setup: mock.OneOf(CallA("a"), CallA("b")),
+		call:  callA(),
+		expectFailures: []string{
+			mock.NewErrBranchNotTaken(-1, -1).Error(),
+		},
+	},
+	"one-of-branch-taken": {
+		setup: mock.OneOf(CallA("a"), CallA("b")),
+		call:  callA("b"),
+	},
+	"one-of-branch-taken-detached": {
+		setup: mock.Chain(mock.OneOf(CallA("a"), CallA("b")), CallA("c")),
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			go callA("a", "c")(nil, mocks)
+		},
+	},
+}
+
+func TestOneOfWait(t *testing.T) {
+	test.Map(t, oneOfWaitTestCases).
+		Timeout(time.Second).
+		Run(func(t test.Test, param OneOfWaitParams) {
+			// Given
+			reporter := &graphT{}
+			mocks := mock.NewMocks(reporter).Expect(param.setup)
+			param.call(t, mocks)
+
+			// When
+			mocks.Wait()
+
+			// Then
+			assert.Equal(t, param.expectFailures, reporter.failures)
+		})
+}
@@ -0,0 +1,156 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"go.uber.org/mock/gomock"
+
+	ireflect "github.com/tkrop/go-testing/internal/reflect"
+)
+
+// Captor is a `gomock.Matcher` that matches any argument of type `T` and
+// records the argument of every consumed mock call, so that the captured
+// values can be asserted after the mock calls have been consumed, e.g. after
+// [Mocks.Wait]. The captor is safe for concurrent calls.
+type Captor[T any] struct {
+	// Mutex to synchronize concurrent captures.
+	mutex sync.Mutex
+	// Mock calls the captor is attached to.
+	calls map[*Call]bool
+	// Captured argument values in order of consumption.
+	values []T
+}
+
+// Capture creates a new argument captor for arguments of type `T`. The captor
+// is used as matcher in the mock call setup and combines naturally with the
+// call back functions created by [Mocks.Do], [Mocks.Return], and [Mocks.Call]:
+//
+// ```go
+//
+//	captor := mock.Capture[*Request]()
+//	mocks := mock.NewMocks(t).Expect(func(mocks *mock.Mocks) any {
+//		return mock.Get(mocks, NewMockService).EXPECT().
+//			Call(captor).DoAndReturn(mocks.Do(Service.Call, nil))
+//	})
+//
+//	unit.Run(...)
+//	mocks.Wait()
+//
+//	assert.Equal(t, "value", captor.Value().Field)
+//
+// ```
+//
+// *Note:* the argument is only captured when the mock call is consumed, i.e.
+// not if the call is only matched. This requires the mock call to be set up
+// via the mock handler, e.g. using [Mocks.Expect].
+func Capture[T any]() *Captor[T] {
+	return &Captor[T]{calls: map[*Call]bool{}, values: []T{}}
+}
+
+// Matches returns whether the actual value is of type `T`. A `nil` value is
+// matched, if `T` is a nilable type.
+func (c *Captor[T]) Matches(got any) bool {
+	_, ok := got.(T)
+	return ok ||
+		(got == nil && nilable(reflect.TypeOf((*T)(nil)).Elem()))
+}
+
+// String returns a string representation of the captor.
+func (c *Captor[T]) String() string {
+	return fmt.Sprintf("captures %v", reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Value returns the last captured argument value, or the zero value of type
+// `T`, if no argument has been captured yet.
+func (c *Captor[T]) Value() T {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.values) == 0 {
+		var zero T
+		return zero
+	}
+	return c.values[len(c.values)-1]
+}
+
+// All returns a copy of all captured argument values in order of capturing.
+func (c *Captor[T]) All() []T {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]T{}, c.values...)
+}
+
+// attach attaches the captor to the given mock call and returns whether it
+// was not attached before.
+func (c *Captor[T]) attach(call *Call) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.calls[call] {
+		return false
+	}
+	c.calls[call] = true
+	return true
+}
+
+// capture captures the given argument value of a consumed mock call.
+func (c *Captor[T]) capture(got any) {
+	value, _ := got.(T)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values = append(c.values, value)
+}
+
+// captor is the common interface of all argument captors.
+type captor interface {
+	// attach attaches the captor to the given mock call.
+	attach(call *Call) bool
+	// capture captures the argument value of a consumed mock call.
+	capture(got any)
+}
+
+// captureArgs attaches all captors used as argument matchers of the given mock
+// calls, so that the arguments are captured by an action of the mock call,
+// when it is consumed. The action is run first to capture the arguments before
+// any call back function is executed.
+func captureArgs(calls any) {
+	for _, call := range callsOf(calls) {
+		value := ireflect.ValueOf(call).Elem()
+		matchers, _ := ireflect.FieldByName(value, "args").([]gomock.Matcher)
+		for index, matcher := range matchers {
+			if captor, ok := matcher.(captor); ok && captor.attach(call) {
+				prependAction(value, func(args []any) []any {
+					if index < len(args) {
+						captor.capture(args[index])
+					}
+					return nil
+				})
+			}
+		}
+	}
+}
+
+// prependAction adds the given action in front of the actions of the given
+// mock call value.
+func prependAction(value reflect.Value, action func([]any) []any) {
+	if field, ok := value.Type().FieldByName("actions"); ok {
+		actions := ireflect.FieldValueOf(value, field.Index[0])
+		actions.Set(reflect.ValueOf(append([]func([]any) []any{action},
+			actions.Interface().([]func([]any) []any)...)))
+	}
+}
+
+// nilable returns whether values of the given type can be `nil`.
+func nilable(rtype reflect.Type) bool {
+	switch rtype.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice,
+		reflect.Func, reflect.Chan:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,201 @@
+package mock_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+type CaptureParams struct {
+	matches      func(got any) (bool, any, any, string)
+	got          any
+	expectMatch  bool
+	expectValue  any
+	expectAll    any
+	expectString string
+}
+
+// capture creates a captor of type `T` and returns a function matching the
+// given value returning the match result, the captured values, and the string
+// representation of the captor.
+func capture[T any]() func(got any) (bool, any, any, string) {
+	return func(got any) (bool, any, any, string) {
+		captor := mock.Capture[T]()
+		match := captor.Matches(got)
+		return match, captor.Value(), captor.All(), captor.String()
+	}
+}
+
+var captureTestCases = map[string]CaptureParams{
+	"string-match": {
+		matches:      capture[string](),
+		got:          "value",
+		expectMatch:  true,
+		expectValue:  "",
+		expectAll:    []string{},
+		expectString: "captures string",
+	},
+	"string-mismatch": {
+		matches:      capture[string](),
+		got:          1,
+		expectValue:  "",
+		expectAll:    []string{},
+		expectString: "captures string",
+	},
+	"string-nil": {
+		matches:      capture[string](),
+		expectValue:  "",
+		expectAll:    []string{},
+		expectString: "captures string",
+	},
+	"pointer-match": {
+		matches:      capture[*int](),
+		got:          test.Ptr(1),
+		expectMatch:  true,
+		expectValue:  (*int)(nil),
+		expectAll:    []*int{},
+		expectString: "captures *int",
+	},
+	"pointer-nil": {
+		matches:      capture[*int](),
+		expectMatch:  true,
+		expectValue:  (*int)(nil),
+		expectAll:    []*int{},
+		expectString: "captures *int",
+	},
+	"any-match": {
+		matches:      capture[any](),
+		got:          1,
+		expectMatch:  true,
+		expectAll:    []any{},
+		expectString: "captures interface {}",
+	},
+	"error-nil": {
+		matches:      capture[error](),
+		expectMatch:  true,
+		expectAll:    []error{},
+		expectString: "captures error",
+	},
+}
+
+func TestCapture(t *testing.T) {
+	test.Map(t, captureTestCases).
+		Run(func(t test.Test, param CaptureParams) {
+			// When
+			match, value, all, str := param.matches(param.got)
+
+			// Then
+			assert.Equal(t, param.expectMatch, match)
+			assert.Equal(t, param.expectValue, value)
+			assert.Equal(t, param.expectAll, all)
+			assert.Equal(t, param.expectString, str)
+		})
+}
+
+func TestCaptureCalls(t *testing.T) {
+	// Given
+	captor := mock.Capture[string]()
+	mocks := mock.NewMocks(t).Expect(func(mocks *mock.Mocks) any {
+		return mock.Get(mocks, NewMockIFace[string]).EXPECT().
+			CallB(captor).Times(mocks.Times(10)).
+			DoAndReturn(mocks.Call(IFace[string].CallB,
+				func(args ...any) []any {
+					return []any{args[0]}
+				}))
+	})
+	iface := mock.Get(mocks, NewMockIFace[string])
+
+	// When
+	wg := sync.WaitGroup{}
+	for _, input := range []string{"a", "b", "c", "d", "e"} {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			iface.CallB(input)
+		}()
+		go func() {
+			defer wg.Done()
+			iface.CallB(input)
+		}()
+	}
+	wg.Wait()
+	mocks.Wait()
+
+	// Then
+	assert.ElementsMatch(t, []string{
+		"a", "a", "b", "b", "c", "c", "d", "d", "e", "e",
+	}, captor.All())
+	assert.Contains(t, []string{"a", "b", "c", "d", "e"}, captor.Value())
+}
+
+// CaptureB creates a mock call setup for `CallB` capturing the input using the
+// given captor and returning the input as output.
+func CaptureB(captor *mock.Captor[string]) mock.SetupFunc {
+	return func(mocks *mock.Mocks) any {
+		return mock.Get(mocks, NewMockIFace[string]).EXPECT().
+			CallB(captor).DoAndReturn(mocks.Call(IFace[string].CallB,
+			func(args ...any) []any {
+				return []any{args[0]}
+			}))
+	}
+}
+
+type CaptureConsumedParams struct {
+	setup  func(*mock.Captor[string]) mock.SetupFunc
+	call   func(IFace[string])
+	expect []string
+}
+
+var captureConsumedTestCases = map[string]CaptureConsumedParams{
+	"expect": {
+		setup: func(captor *mock.Captor[string]) mock.SetupFunc {
+			return CaptureB(captor)
+		},
+		call: func(iface IFace[string]) {
+			iface.CallB("a")
+		},
+		expect: []string{"a"},
+	},
+	"setup": {
+		setup: func(captor *mock.Captor[string]) mock.SetupFunc {
+			return mock.Setup(CaptureB(captor))
+		},
+		call: func(iface IFace[string]) {
+			iface.CallB("a")
+		},
+		expect: []string{"a"},
+	},
+	"matched-not-consumed": {
+		setup: func(captor *mock.Captor[string]) mock.SetupFunc {
+			return mock.Setup(
+				mock.Chain(CallA("a"), CaptureB(captor)),
+				CallB("b", "b"),
+			)
+		},
+		call: func(iface IFace[string]) {
+			iface.CallB("b")
+			iface.CallA("a")
+			iface.CallB("c")
+		},
+		expect: []string{"c"},
+	},
+}
+
+func TestCaptureConsumed(t *testing.T) {
+	test.Map(t, captureConsumedTestCases).
+		Run(func(t test.Test, param CaptureConsumedParams) {
+			// Given
+			captor := mock.Capture[string]()
+			mocks := mock.NewMocks(t).Expect(param.setup(captor))
+
+			// When
+			param.call(mock.Get(mocks, NewMockIFace[string]))
+
+			// Then
+			assert.Equal(t, param.expect, captor.All())
+		})
+}
@@ -0,0 +1,161 @@
+package mock
+
+import (
+	"reflect"
+
+	ireflect "github.com/tkrop/go-testing/internal/reflect"
+)
+
+// Comparer sets up a custom comparison function for values of type `T` that
+// is applied when matching values and when rendering the diff, e.g. to treat
+// `time.Time` values with different monotonic clock readings as equal or to
+// compare floats within a tolerance. If `T` is an interface type, the function
+// is applied to all values implementing the interface.
+func Comparer[T any](compare func(a, b T) bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.Comparer(reflect.TypeFor[T](), func(a, b any) bool {
+			ta, _ := a.(T)
+			tb, _ := b.(T)
+			return compare(ta, tb)
+		})
+	}
+}
+
+// Comparer sets up a custom comparison function for values of the given type
+// that is applied when matching values and when rendering the diff. Use the
+// generic [Comparer] config function for a type safe setup. If the given type
+// is an interface type, the function is applied to all values implementing
+// the interface, unless a comparer for the exact value type is set up.
+func (c *DiffConfig) Comparer(
+	rtype reflect.Type, compare func(a, b any) bool,
+) {
+	if c.comparers == nil {
+		c.comparers = map[reflect.Type]func(a, b any) bool{}
+	}
+	if _, ok := c.comparers[rtype]; !ok &&
+		rtype.Kind() == reflect.Interface {
+		c.ifaces = append(c.ifaces, rtype)
+	}
+	c.comparers[rtype] = compare
+}
+
+// comparer returns the custom comparer for values of the given type. A
+// comparer for the exact type takes precedence over comparers for interface
+// types implemented by the given type, which are tried in order of setup.
+func (c *DiffConfig) comparer(rtype reflect.Type) (func(a, b any) bool, bool) {
+	if compare, ok := c.comparers[rtype]; ok {
+		return compare, true
+	}
+	for _, itype := range c.ifaces {
+		if rtype.Implements(itype) {
+			return c.comparers[itype], true
+		}
+	}
+	return nil, false
+}
+
+// custom returns whether custom comparers or ignored fields are set up, so
+// that values need to be compared by walking their fields.
+func (c *DiffConfig) custom() bool {
+	return len(c.comparers) != 0 || len(c.ignores) != 0 || c.ignoreTag != ""
+}
+
+// align returns a copy of the actual value where all ignored fields and all
+// values that are equal according to a custom comparer are replaced by the
+// expected values. This hides these differences when rendering the diff.
+func (c *DiffConfig) align(want, got any) any {
+	walker := &pathWalker{config: c, visited: map[[2]uintptr]bool{}}
+	value := walker.align("", reflect.ValueOf(want), reflect.ValueOf(got))
+	return value.Interface()
+}
+
+// align returns a copy of the actual value at the given field path, where
+// all ignored fields and all values that are equal according to a custom
+// comparer are replaced by the expected values.
+func (w *pathWalker) align(path string, want, got reflect.Value) reflect.Value {
+	if !want.IsValid() || !got.IsValid() || want.Type() != got.Type() {
+		return got
+	} else if w.ignored(path) {
+		return want
+	} else if compare, ok := w.config.comparer(want.Type()); ok {
+		if compare(want.Interface(), got.Interface()) {
+			return want
+		}
+		return got
+	}
+
+	switch want.Kind() {
+	case reflect.Ptr:
+		key := [2]uintptr{want.Pointer(), got.Pointer()}
+		if want.IsNil() || got.IsNil() || w.visited[key] {
+			return got
+		}
+		w.visited[key] = true
+		value := reflect.New(got.Type().Elem())
+		value.Elem().Set(w.align(path, want.Elem(), got.Elem()))
+		return value
+
+	case reflect.Interface:
+		if want.IsNil() || got.IsNil() {
+			return got
+		}
+		value := reflect.New(got.Type()).Elem()
+		value.Set(w.align(path, want.Elem(), got.Elem()))
+		return value
+
+	case reflect.Struct:
+		value := reflect.New(got.Type()).Elem()
+		for index := 0; index < got.NumField(); index++ {
+			field := ireflect.FieldValueOf(got, index)
+			if w.config.ignoreTag != "" && got.Type().Field(index).
+				Tag.Get(w.config.ignoreTag) == "-" {
+				field = ireflect.FieldValueOf(want, index)
+			} else {
+				field = w.align(path+"."+got.Type().Field(index).Name,
+					ireflect.FieldValueOf(want, index), field)
+			}
+			ireflect.FieldValueOf(value, index).Set(field)
+		}
+		return value
+
+	case reflect.Slice:
+		if want.IsNil() || got.IsNil() {
+			return got
+		}
+		value := reflect.MakeSlice(got.Type(), got.Len(), got.Len())
+		w.alignIndex(path, want, got, value)
+		return value
+
+	case reflect.Array:
+		value := reflect.New(got.Type()).Elem()
+		w.alignIndex(path, want, got, value)
+		return value
+
+	case reflect.Map:
+		if want.IsNil() || got.IsNil() {
+			return got
+		}
+		value := reflect.MakeMapWithSize(got.Type(), got.Len())
+		for _, key := range got.MapKeys() {
+			value.SetMapIndex(key, w.align(path+"["+mapKey(key)+"]",
+				want.MapIndex(key), got.MapIndex(key)))
+		}
+		return value
+
+	default:
+		return got
+	}
+}
+
+// alignIndex aligns the elements of the actual slice or array value with the
+// elements of the expected value storing them in the given target value.
+func (w *pathWalker) alignIndex(path string, want, got, value reflect.Value) {
+	for index := 0; index < got.Len(); index++ {
+		if index < want.Len() {
+			value.Index(index).Set(w.align(indexKey(path, index),
+				want.Index(index), got.Index(index)))
+		} else {
+			value.Index(index).Set(got.Index(index))
+		}
+	}
+}
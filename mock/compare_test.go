@@ -0,0 +1,197 @@
+package mock_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+// now is a time with monotonic clock reading, that is equal to the same time
+// without monotonic clock reading, but not deep equal.
+var now = time.Now()
+
+// timeEqual compares times ignoring the monotonic clock reading.
+func timeEqual(a, b time.Time) bool {
+	return a.Equal(b)
+}
+
+// floatEqual compares floats within a small tolerance.
+func floatEqual(a, b float64) bool {
+	return math.Abs(a-b) < 0.01
+}
+
+// stringEqual compares values by their string representation.
+func stringEqual(a, b fmt.Stringer) bool {
+	return a.String() == b.String()
+}
+
+// Label is a value with a string representation ignoring the revision.
+type Label struct {
+	Name string
+	Rev  int
+}
+
+// String returns the name of the label.
+func (l Label) String() string {
+	return l.Name
+}
+
+type Measure struct {
+	ID    string
+	Time  time.Time
+	Value float64
+	Items []Item
+}
+
+type CompareParams struct {
+	config      []mock.DiffOption
+	want        any
+	got         any
+	expect      bool
+	expectDiff  string
+	expectPaths []string
+}
+
+var compareTestCases = map[string]CompareParams{
+	"time-without-comparer": {
+		want:   now,
+		got:    now.Round(0),
+		expect: false,
+	},
+	"time-comparer": {
+		config: []mock.DiffOption{mock.Comparer(timeEqual)},
+		want:   now,
+		got:    now.Round(0),
+		expect: true,
+	},
+	"float-comparer": {
+		config: []mock.DiffOption{mock.Comparer(floatEqual)},
+		want:   Measure{Value: 1.001},
+		got:    Measure{Value: 1.002},
+		expect: true,
+	},
+	"float-comparer-mismatch": {
+		config: []mock.DiffOption{mock.Comparer(floatEqual)},
+		want:   Measure{ID: "a", Value: 1.0},
+		got:    Measure{ID: "a", Value: 1.1},
+		expect: false,
+		expectDiff: diff("5,7 +5,7", ""+
+			"     ext: (int64) 0,\n"+
+			"     loc: (*time.Location)(<nil>)\n"+
+			"   },\n"+
+			"-  Value: (float64) 1,\n"+
+			"+  Value: (float64) 1.1,\n"+
+			"   Items: ([]mock_test.Item) <nil>\n"+
+			" }\n \n"),
+		expectPaths: []string{".Value: want 1, got 1.1"},
+	},
+	"comparer-in-slice": {
+		config: []mock.DiffOption{mock.Comparer(timeEqual)},
+		want:   []Measure{{Time: now}, {Time: now}},
+		got:    []Measure{{Time: now.Round(0)}, {Time: now.Round(0)}},
+		expect: true,
+	},
+	"interface-comparer": {
+		config: []mock.DiffOption{mock.Comparer(stringEqual)},
+		want:   Label{Name: "a", Rev: 1},
+		got:    Label{Name: "a", Rev: 2},
+		expect: true,
+	},
+	"interface-comparer-in-slice": {
+		config: []mock.DiffOption{mock.Comparer(stringEqual)},
+		want:   []Label{{Name: "a", Rev: 1}, {Name: "b", Rev: 1}},
+		got:    []Label{{Name: "a", Rev: 2}, {Name: "b", Rev: 3}},
+		expect: true,
+	},
+	"interface-comparer-mismatch": {
+		config: []mock.DiffOption{mock.Comparer(stringEqual)},
+		want:   []Label{{Name: "a", Rev: 1}},
+		got:    []Label{{Name: "b", Rev: 1}},
+		expect: false,
+		expectPaths: []string{
+			"[0]: want mock_test.Label{Name:\"a\", Rev:1}, " +
+				"got mock_test.Label{Name:\"b\", Rev:1}",
+		},
+	},
+	"exact-comparer-before-interface": {
+		config: []mock.DiffOption{
+			mock.Comparer(stringEqual),
+			mock.Comparer(func(a, b Label) bool { return a == b }),
+		},
+		want:   Label{Name: "a", Rev: 1},
+		got:    Label{Name: "a", Rev: 2},
+		expect: false,
+	},
+	"ignore-id": {
+		config: []mock.DiffOption{mock.Ignore(".ID")},
+		want:   Measure{ID: "generated-1", Value: 1},
+		got:    Measure{ID: "generated-2", Value: 1},
+		expect: true,
+	},
+	"ignore-id-mismatch": {
+		config: []mock.DiffOption{mock.Ignore(".ID", ".Items[*].note")},
+		want: Measure{ID: "generated-1", Items: []Item{
+			{Price: 1, note: "a"},
+		}},
+		got: Measure{ID: "generated-2", Items: []Item{
+			{Price: 2, note: "b"},
+		}},
+		expect: false,
+		expectDiff: diff("8,7 +8,7", ""+
+			"   Value: (float64) 0,\n"+
+			"   Items: ([]mock_test.Item) (len=1) {\n"+
+			"     (mock_test.Item) {\n"+
+			"-      Price: (int) 1,\n"+
+			"+      Price: (int) 2,\n"+
+			"       note: (string) (len=1) \"a\"\n"+
+			"     }\n"+
+			"   }\n"),
+		expectPaths: []string{".Items[0].Price: want 1, got 2"},
+	},
+	"ignore-tag": {
+		config: []mock.DiffOption{mock.IgnoreTag("diff")},
+		want:   Order{ID: "a", Tags: []string{"a"}},
+		got:    Order{ID: "b", Tags: []string{"a"}},
+		expect: true,
+	},
+}
+
+func TestCompare(t *testing.T) {
+	test.Map(t, compareTestCases).
+		Run(func(t test.Test, param CompareParams) {
+			// Given
+			config := mock.NewDiffConfig(param.config...)
+
+			// When
+			result := config.Match(param.want, param.got)
+
+			// Then
+			assert.Equal(t, param.expect, result)
+			if param.expectDiff != "" {
+				assert.Equal(t, param.expectDiff,
+					config.Diff(param.want, param.got))
+			}
+			if param.expectPaths != nil {
+				assert.Equal(t, param.expectPaths,
+					config.Paths(param.want, param.got))
+			}
+		})
+}
+
+func TestCompareEqual(t *testing.T) {
+	// Given
+	mocks := mock.NewMocks(t, mock.Comparer(timeEqual), mock.Ignore(".ID"))
+	matcher := mocks.Equal(Measure{ID: "a", Time: now})
+
+	// When
+	result := matcher.Matches(Measure{ID: "b", Time: now.Round(0)})
+
+	// Then
+	assert.True(t, result)
+}
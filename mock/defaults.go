@@ -0,0 +1,72 @@
+package mock
+
+import (
+	"runtime"
+	"strings"
+	gosync "sync"
+
+	"github.com/tkrop/go-testing/internal/reflect"
+)
+
+// defaults is the table of default results of mock calls answered in spy and
+// stub mode.
+type defaults struct {
+	// Mutex to synchronize concurrent access.
+	mutex gosync.Mutex
+	// Default results of mock calls by method name.
+	results map[string][]defaultResults
+}
+
+// defaultResults are the default results of an interface method.
+type defaultResults struct {
+	// Interface type providing the method.
+	iface reflect.Type
+	// Default results of the method.
+	results []any
+}
+
+// newDefaults creates a new empty table of default results.
+func newDefaults() *defaults {
+	return &defaults{results: map[string][]defaultResults{}}
+}
+
+// Default sets up the default results for the given interface method, e.g.
+// `Service.Call`, that are returned by mock calls answered in spy mode (see
+// [Spy]) and stub mode (see [Stub]). The results must match the return values
+// of the method, otherwise the function panics.
+func (mocks *Mocks) Default(fn any, results ...any) *Mocks {
+	ftype := reflect.TypeOf(fn)
+	reflect.ValuesOut(ftype, false, results...)
+
+	mocks.defaults.mutex.Lock()
+	defer mocks.defaults.mutex.Unlock()
+
+	name := methodName(fn)
+	mocks.defaults.results[name] = append([]defaultResults{{
+		iface: ftype.In(0), results: results,
+	}}, mocks.defaults.results[name]...)
+	return mocks
+}
+
+// answer returns the default results of the given method of the given mock
+// type, or zero values, if no default results are set up for the method.
+func (d *defaults) answer(
+	mtype reflect.Type, method string, ftype reflect.Type,
+) []reflect.Value {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, deflt := range d.results[method] {
+		if mtype.Implements(deflt.iface) {
+			return reflect.ValuesOut(ftype, false, deflt.results...)
+		}
+	}
+	return reflect.ValuesOut(ftype, true)
+}
+
+// methodName returns the name of the method of the given method expression,
+// e.g. `Call` for `Service.Call`.
+func methodName(fn any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -7,5 +7,6 @@ package mock
 //revive:disable:line-length-limit // go:generate line length
 
 //go:generate mockgen -package=mock_test -destination=mock_iface_test.go -source=mocks_test.go  IFace,XFace
+//go:generate mockgen -package=mock_test -destination=mock_typed_test.go -source=mocks_test.go -typed -mock_names=IFace=MockTypedIFace,XFace=MockTypedXFace IFace,XFace
 
 //revive:enable:line-length-limit
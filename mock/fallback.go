@@ -0,0 +1,162 @@
+package mock
+
+import (
+	gosync "sync"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/tkrop/go-testing/internal/reflect"
+)
+
+// fallbacks is the set of catch-all mock calls answering all calls of a mock
+// method, that are not matching any regular expected call.
+type fallbacks struct {
+	// Mutex to synchronize concurrent access.
+	mutex gosync.Mutex
+	// Catch-all mock calls set up as fallback.
+	calls map[*Call]bool
+}
+
+// newFallbacks creates a new empty set of catch-all mock calls.
+func newFallbacks() *fallbacks {
+	return &fallbacks{calls: map[*Call]bool{}}
+}
+
+// add sets up a catch-all mock call of the given method of the given mock,
+// answering all calls, that are not matching any regular expected call, using
+// the given answer function. Since regular expected calls may be set up after
+// the catch-all mock call, e.g. via `EXPECT()`, the catch-all mock call moves
+// itself behind the regular expected calls of the method when called, and
+// repeats the call, if it was shadowing any regular expected call.
+func (f *fallbacks) add(
+	ctrl *Controller, mock any, method string,
+	answer func(in []reflect.Value) []reflect.Value,
+) *Call {
+	value := reflect.ValueOf(mock).MethodByName(method)
+	mtype := value.Type()
+	args := make([]any, 0, mtype.NumIn())
+	for range mtype.NumIn() {
+		args = append(args, gomock.Any())
+	}
+
+	call := ctrl.RecordCallWithMethodType(mock, method, mtype, args...).
+		AnyTimes().DoAndReturn(reflect.MakeFuncOf(mtype,
+		func(in []reflect.Value) []reflect.Value {
+			if !f.last(ctrl, mock, method) {
+				return answer(in)
+			} else if mtype.IsVariadic() {
+				return value.CallSlice(in)
+			}
+			return value.Call(in)
+		}))
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.calls[call] = true
+	return call
+}
+
+// Delegate sets up the given mock to forward all calls, that are not matching
+// a regular expected call, to the real implementation provided by the given
+// function. This is used by delegating mocks generated by `cmd/mock`, where
+// the decision is made once by the mock controller when matching the call. If
+// the function provides no real implementation, the call is reported as
+// unexpected.
+func Delegate(ctrl *Controller, mock any, delegate func() any) {
+	fallbacks, value := newFallbacks(), reflect.ValueOf(mock)
+	for index := range value.NumMethod() {
+		name := value.Type().Method(index).Name
+		if name == "EXPECT" || name == "DELEGATE" {
+			continue
+		}
+
+		mtype := value.Method(index).Type()
+		fallbacks.add(ctrl, mock, name,
+			func(in []reflect.Value) []reflect.Value {
+				target := delegate()
+				if target == nil {
+					ctrl.T.Fatalf("Unexpected call to %T.%v(%v) because: "+
+						"no delegate", mock, name,
+						reflect.StringArgs(reflect.ArgsOf(in...)))
+					return reflect.ValuesOut(mtype, true)
+				}
+
+				method := reflect.ValueOf(target).MethodByName(name)
+				if mtype.IsVariadic() {
+					return method.CallSlice(in)
+				}
+				return method.Call(in)
+			})
+	}
+}
+
+// last moves the catch-all mock calls of the given method of the given mock
+// behind all regular expected calls of the method, so that the regular
+// expected calls are matched first. The function returns whether any regular
+// expected call was shadowed by a catch-all mock call.
+func (f *fallbacks) last(ctrl *Controller, mock any, method string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	shadowed := false
+	withCallSet(ctrl, func(expected, _ reflect.Value) {
+		for _, key := range expected.MapKeys() {
+			if reflect.FieldByName(key, "receiver") != mock ||
+				reflect.FieldByName(key, "fname") != method {
+				continue
+			}
+
+			calls := expected.MapIndex(key).Interface().([]*Call)
+			ordered := make([]*Call, 0, len(calls))
+			fallback := make([]*Call, 0, len(calls))
+			for _, call := range calls {
+				if f.calls[call] {
+					fallback = append(fallback, call)
+				} else {
+					shadowed = shadowed || len(fallback) > 0
+					ordered = append(ordered, call)
+				}
+			}
+			if shadowed {
+				expected.SetMapIndex(key,
+					reflect.ValueOf(append(ordered, fallback...)))
+			}
+		}
+	})
+	return shadowed
+}
+
+// withCallSet calls the given function with the maps of expected and
+// exhausted calls of the mock controller while holding the lock of the call
+// set.
+func (mocks *Mocks) withCallSet(call func(expected, exhausted reflect.Value)) {
+	withCallSet(mocks.Ctrl, call)
+}
+
+// withCallSet calls the given function with the maps of expected and
+// exhausted calls of the given mock controller while holding the lock of the
+// call set.
+func withCallSet(
+	ctrl *Controller, call func(expected, exhausted reflect.Value),
+) {
+	value := reflect.ValueOf(ctrl).Elem()
+	cset := reflect.ValueOf(reflect.FieldByName(value, "expectedCalls"))
+	if !cset.IsValid() || cset.IsNil() {
+		return
+	}
+	mutex, ok := reflect.FieldByName(cset.Elem(), "expectedMu").(*gosync.Mutex)
+	if !ok {
+		return
+	}
+	efield, eok := cset.Elem().Type().FieldByName("expected")
+	xfield, xok := cset.Elem().Type().FieldByName("exhausted")
+	if !eok || !xok {
+		return
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	call(reflect.FieldValueOf(cset.Elem(), efield.Index[0]),
+		reflect.FieldValueOf(cset.Elem(), xfield.Index[0]))
+}
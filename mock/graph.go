@@ -0,0 +1,400 @@
+package mock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	gosync "sync"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/tkrop/go-testing/internal/reflect"
+)
+
+// GoTestingMockGraphVar is the environment variable used to enable rendering
+// of the mock call graph for every test using the given graph format, i.e.
+// `mermaid` or `dot`. For tests using [testing.T] directly, the relevant sub
+// graph is logged on failures of the mock controller, and the complete graph
+// is logged on test cleanup, if the test has failed.
+const GoTestingMockGraphVar = "GO_TESTING_MOCK_GRAPH"
+
+// GraphFormat defines the text format for rendering mock call graphs.
+type GraphFormat int
+
+const (
+	// NoGraph format to not render mock call graphs.
+	NoGraph GraphFormat = 0
+	// Mermaid format to render mock call graphs as Mermaid flowchart.
+	Mermaid GraphFormat = 1
+	// DOT format to render mock call graphs as Graphviz DOT digraph.
+	DOT GraphFormat = 2
+)
+
+// String return string representation of graph format.
+func (f GraphFormat) String() string {
+	switch f {
+	case NoGraph:
+		return "None"
+	case Mermaid:
+		return "Mermaid"
+	case DOT:
+		return "DOT"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseGraphFormat parses the given graph format name case insensitive. It
+// returns [NoGraph] for all unknown graph format names.
+func ParseGraphFormat(name string) GraphFormat {
+	switch strings.ToLower(name) {
+	case "mermaid":
+		return Mermaid
+	case "dot":
+		return DOT
+	default:
+		return NoGraph
+	}
+}
+
+// graph is the graph of mock calls and their ordering constraints set up via
+// [Mocks.Expect].
+type graph struct {
+	// Mutex to synchronize concurrent access.
+	mutex gosync.Mutex
+	// Graph format used for failure messages.
+	format GraphFormat
+	// Mock calls in order of setup.
+	calls []*Call
+	// Prerequisite mock calls per mock call.
+	prereqs map[*Call][]*Call
+}
+
+// graphReporter is the test reporter of the mock controller that is logging
+// the relevant sub graph of mock calls on failures.
+type graphReporter struct {
+	gomock.TestHelper
+	// Mock handler providing the mock call graph.
+	mocks *Mocks
+}
+
+// graphCleanupReporter is the test reporter of the mock controller that is
+// logging the relevant sub graph of mock calls on failures for test reporters
+// supporting cleanup functions, which is required by `gomock` to
+// allow finishing the mock controller multiple times.
+type graphCleanupReporter struct {
+	*graphReporter
+}
+
+// newGraph creates a new empty mock call graph.
+func newGraph() *graph {
+	return &graph{prereqs: map[*Call][]*Call{}}
+}
+
+// GraphOnFailure sets up the graph format used to log the relevant sub graph
+// of mock calls on failures of the mock controller, i.e. the mock calls
+// related to the failure and their prerequisite mock calls with already
+// satisfied mock calls marked. The failure messages stay unchanged, and the
+// sub graph is only logged, if the test reporter supports logging. Default is
+// [NoGraph], or the graph format set via the [GoTestingMockGraphVar]
+// environment variable.
+func GraphOnFailure(format GraphFormat) ConfigFunc {
+	return func(mocks *Mocks) {
+		mocks.graph.mutex.Lock()
+		mocks.graph.format = format
+		mocks.graph.mutex.Unlock()
+
+		t, wrapped := unwrapGraph(mocks.Ctrl.T)
+		if format == NoGraph && wrapped {
+			mocks.Ctrl.T = t
+		} else if format != NoGraph && !wrapped {
+			reporter := &graphReporter{TestHelper: t, mocks: mocks}
+			if _, ok := t.(interface{ Cleanup(cleanup func()) }); ok {
+				mocks.Ctrl.T = &graphCleanupReporter{graphReporter: reporter}
+			} else {
+				mocks.Ctrl.T = reporter
+			}
+		}
+	}
+}
+
+// unwrapGraph returns the original test reporter of the given test reporter
+// and whether the test reporter is logging mock call graphs on failures.
+func unwrapGraph(t gomock.TestHelper) (gomock.TestHelper, bool) {
+	switch reporter := t.(type) {
+	case *graphReporter:
+		return reporter.TestHelper, true
+	case *graphCleanupReporter:
+		return reporter.TestHelper, true
+	default:
+		return t, false
+	}
+}
+
+// graphFromEnv sets up the graph format from the [GoTestingMockGraphVar]
+// environment variable, and registers the given test to log the complete mock
+// call graph on test cleanup, if the test has failed. The graph is only set
+// up for tests using [testing.T] directly, since other test reporters, e.g.
+// mocked test reporters validating failures, may not expect any logs.
+func graphFromEnv(t gomock.TestReporter) ConfigFunc {
+	return func(mocks *Mocks) {
+		format := ParseGraphFormat(os.Getenv(GoTestingMockGraphVar))
+		t, ok := t.(*testing.T)
+		if format == NoGraph || !ok {
+			return
+		}
+
+		GraphOnFailure(format)(mocks)
+		t.Cleanup(func() {
+			if !t.Failed() {
+				return
+			} else if graph := mocks.Graph(format); graph != "" {
+				t.Logf("mock call graph:\n%s", graph)
+			}
+		})
+	}
+}
+
+// Graph renders the graph of all mock calls set up via [Mocks.Expect] and
+// their ordering constraints using the given graph format. Already satisfied
+// mock calls are marked in the graph. The graph is empty, if no mock calls
+// are set up or the graph format is [NoGraph].
+func (mocks *Mocks) Graph(format GraphFormat) string {
+	return mocks.graph.render(format, mocks.graph.all())
+}
+
+// add adds the given mock call tree to the graph. Since `gomock` drops the
+// prerequisite mock calls when a mock call is consumed, the prerequisites
+// must be recorded directly after setting up the ordering constraints.
+func (g *graph) add(calls any) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, call := range callsOf(calls) {
+		if _, ok := g.prereqs[call]; ok {
+			continue
+		}
+		g.calls = append(g.calls, call)
+		g.prereqs[call] = prereqsOf(call)
+	}
+}
+
+// all returns all mock calls of the graph.
+func (g *graph) all() []*Call {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return append([]*Call{}, g.calls...)
+}
+
+// related returns the mock calls related to a failure with the given failure
+// arguments, i.e. the mock call itself in case of a missing mock call or the
+// mock calls of the receiver method in case of an unexpected mock call, and
+// all their prerequisite mock calls.
+func (g *graph) related(args ...any) []*Call {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	seeds := []*Call{}
+	if len(args) > 0 {
+		if call, ok := args[0].(*Call); ok {
+			seeds = append(seeds, call)
+		}
+	}
+	if len(args) > 1 {
+		if method, ok := args[1].(string); ok {
+			for _, call := range g.calls {
+				value := reflect.ValueOf(call).Elem()
+				if reflect.FieldByName(value, "receiver") == args[0] &&
+					reflect.FieldByName(value, "method") == method {
+					seeds = append(seeds, call)
+				}
+			}
+		}
+	}
+
+	related := map[*Call]bool{}
+	for len(seeds) > 0 {
+		call := seeds[len(seeds)-1]
+		seeds = seeds[:len(seeds)-1]
+		if _, ok := g.prereqs[call]; ok && !related[call] {
+			related[call] = true
+			seeds = append(seeds, g.prereqs[call]...)
+		}
+	}
+
+	calls := make([]*Call, 0, len(related))
+	for _, call := range g.calls {
+		if related[call] {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// render renders the given mock calls of the graph with their ordering
+// constraints using the given graph format.
+func (g *graph) render(format GraphFormat, calls []*Call) string {
+	if len(calls) == 0 {
+		return ""
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	ids := make(map[*Call]string, len(calls))
+	for index, call := range calls {
+		ids[call] = "n" + strconv.Itoa(index)
+	}
+
+	switch format {
+	case Mermaid:
+		return g.mermaid(calls, ids)
+	case DOT:
+		return g.dot(calls, ids)
+	default:
+		return ""
+	}
+}
+
+// mermaid renders the given mock calls as Mermaid flowchart.
+func (g *graph) mermaid(calls []*Call, ids map[*Call]string) string {
+	builder := strings.Builder{}
+	builder.WriteString("flowchart TD\n")
+	for _, call := range calls {
+		fmt.Fprintf(&builder, "    %s[\"%s\"]\n", ids[call],
+			mermaidReplacer.Replace(labelOf(call)))
+	}
+	for _, call := range calls {
+		for _, prereq := range g.prereqs[call] {
+			if id, ok := ids[prereq]; ok {
+				fmt.Fprintf(&builder, "    %s --> %s\n", id, ids[call])
+			}
+		}
+	}
+	for _, call := range calls {
+		if satisfied(call) {
+			fmt.Fprintf(&builder, "    class %s satisfied\n", ids[call])
+		}
+	}
+	builder.WriteString("    classDef satisfied fill:#9f9,stroke:#090\n")
+	return builder.String()
+}
+
+// dot renders the given mock calls as Graphviz DOT digraph.
+func (g *graph) dot(calls []*Call, ids map[*Call]string) string {
+	builder := strings.Builder{}
+	builder.WriteString("digraph mocks {\n")
+	for _, call := range calls {
+		fmt.Fprintf(&builder, "    %s [label=\"%s\"", ids[call],
+			dotReplacer.Replace(labelOf(call)))
+		if satisfied(call) {
+			builder.WriteString(", style=filled, fillcolor=palegreen")
+		}
+		builder.WriteString("];\n")
+	}
+	for _, call := range calls {
+		for _, prereq := range g.prereqs[call] {
+			if id, ok := ids[prereq]; ok {
+				fmt.Fprintf(&builder, "    %s -> %s;\n", id, ids[call])
+			}
+		}
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+var (
+	// Replacer to escape labels in Mermaid flowcharts.
+	mermaidReplacer = strings.NewReplacer(
+		"\"", "#quot;", "<", "#lt;", ">", "#gt;", "\n", "<br/>",
+	)
+	// Replacer to escape labels in Graphviz DOT digraphs.
+	dotReplacer = strings.NewReplacer(
+		"\\", "\\\\", "\"", "\\\"", "\n", "\\n",
+	)
+)
+
+// labelOf returns the label of the given mock call consisting of the mock
+// method call with the first line of the argument matchers, since matchers
+// may add details of the last mismatch, and the base name of the origin.
+func labelOf(call *Call) string {
+	value := reflect.ValueOf(call).Elem()
+	matchers, _ := reflect.FieldByName(value, "args").([]gomock.Matcher)
+	args := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		arg, _, _ := strings.Cut(matcher.String(), "\n")
+		args = append(args, arg)
+	}
+
+	label := fmt.Sprintf("%T.%v(%s)", reflect.FieldByName(value, "receiver"),
+		reflect.FieldByName(value, "method"), strings.Join(args, ", "))
+	if origin, _ := reflect.FieldByName(value, "origin").(string); origin != "" {
+		label += "\n" + filepath.Base(origin)
+	}
+	return label
+}
+
+// prereqsOf returns the prerequisite mock calls of the given mock call.
+func prereqsOf(call *Call) []*Call {
+	prereqs, _ := reflect.FieldByName(
+		reflect.ValueOf(call).Elem(), "preReqs").([]*Call)
+	return append([]*Call{}, prereqs...)
+}
+
+// satisfied returns whether the given mock call is already satisfied, i.e.
+// called at least the minimum number of times.
+func satisfied(call *Call) bool {
+	calls, _ := reflect.FieldByName(
+		reflect.ValueOf(call).Elem(), "numCalls").(int)
+	return calls >= minCallsOf(call)
+}
+
+// Unwrap returns the original test reporter, e.g. to check its type.
+func (r *graphReporter) Unwrap() gomock.TestHelper {
+	return r.TestHelper
+}
+
+// Errorf reports the error message and logs the relevant sub graph of mock
+// calls.
+func (r *graphReporter) Errorf(format string, args ...any) {
+	r.TestHelper.Helper()
+	r.TestHelper.Errorf(format, args...)
+	r.log(args...)
+}
+
+// Fatalf logs the relevant sub graph of mock calls and reports the fatal
+// message.
+func (r *graphReporter) Fatalf(format string, args ...any) {
+	r.TestHelper.Helper()
+	r.log(args...)
+	r.TestHelper.Fatalf(format, args...)
+}
+
+// Cleanup registers the given cleanup function on the original test reporter.
+func (r *graphCleanupReporter) Cleanup(cleanup func()) {
+	r.TestHelper.(interface{ Cleanup(cleanup func()) }).Cleanup(cleanup)
+}
+
+// log logs the relevant sub graph of mock calls for a failure with the given
+// failure arguments, if available and the test reporter supports logging.
+func (r *graphReporter) log(args ...any) {
+	t, ok := r.TestHelper.(interface {
+		Logf(format string, args ...any)
+	})
+	if !ok {
+		return
+	}
+
+	r.mocks.graph.mutex.Lock()
+	format := r.mocks.graph.format
+	r.mocks.graph.mutex.Unlock()
+
+	graph := r.mocks.graph.render(format, r.mocks.graph.related(args...))
+	if graph != "" {
+		t.Logf("mock call graph:\n%s", graph)
+	}
+}
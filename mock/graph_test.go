@@ -0,0 +1,333 @@
+package mock_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+const (
+	// Mock call label of `CallA` with the given input argument.
+	labelCallA = "*mock_test.MockIFace[string].CallA(string(%q))"
+	// Mock call label of `CallB` with the given input argument.
+	labelCallB = "*mock_test.MockIFace[string].CallB(string(%q))"
+	// Origin of the mock calls set up via `CallA`.
+	originCallA = "mocks_test.go:28"
+	// Origin of the mock calls set up via `CallB`.
+	originCallB = "mocks_test.go:35"
+)
+
+// mermaidNode creates a Mermaid node with the given id, label, and origin.
+func mermaidNode(id, label, input, origin string) string {
+	return fmt.Sprintf("    %s[\"%s<br/>%s\"]\n", id, strings.ReplaceAll(
+		fmt.Sprintf(label, input), "\"", "#quot;"), origin)
+}
+
+// dotNode creates a DOT node with the given id, label, and origin.
+func dotNode(id, label, input, origin, style string) string {
+	return fmt.Sprintf("    %s [label=\"%s\\n%s\"%s];\n", id, strings.ReplaceAll(
+		fmt.Sprintf(label, input), "\"", "\\\""), origin, style)
+}
+
+// graphSetup sets up a chain with a parallel set of mock calls.
+var graphSetup = mock.Chain(
+	CallA("a"),
+	mock.Parallel(CallB("b", "c"), CallA("d")),
+	CallA("e"),
+)
+
+type GraphFormatParams struct {
+	format       mock.GraphFormat
+	expectString string
+	expectParse  mock.GraphFormat
+}
+
+var graphFormatTestCases = map[string]GraphFormatParams{
+	"none": {
+		format:       mock.NoGraph,
+		expectString: "None",
+		expectParse:  mock.NoGraph,
+	},
+	"mermaid": {
+		format:       mock.Mermaid,
+		expectString: "Mermaid",
+		expectParse:  mock.Mermaid,
+	},
+	"dot": {
+		format:       mock.DOT,
+		expectString: "DOT",
+		expectParse:  mock.DOT,
+	},
+	"unknown": {
+		format:       4,
+		expectString: "Unknown",
+		expectParse:  mock.NoGraph,
+	},
+}
+
+func TestGraphFormat(t *testing.T) {
+	test.Map(t, graphFormatTestCases).
+		Run(func(t test.Test, param GraphFormatParams) {
+			// When
+			str := param.format.String()
+			format := mock.ParseGraphFormat(strings.ToLower(str))
+
+			// Then
+			assert.Equal(t, param.expectString, str)
+			assert.Equal(t, param.expectParse, format)
+		})
+}
+
+type GraphParams struct {
+	setup  mock.SetupFunc
+	call   func(*mock.Mocks)
+	format mock.GraphFormat
+	expect string
+}
+
+var graphTestCases = map[string]GraphParams{
+	"no-calls": {
+		format: mock.Mermaid,
+		expect: "",
+	},
+	"no-graph": {
+		setup:  graphSetup,
+		format: mock.NoGraph,
+		expect: "",
+	},
+	"mermaid": {
+		setup:  graphSetup,
+		format: mock.Mermaid,
+		expect: "flowchart TD\n" +
+			mermaidNode("n0", labelCallA, "a", originCallA) +
+			mermaidNode("n1", labelCallB, "b", originCallB) +
+			mermaidNode("n2", labelCallA, "d", originCallA) +
+			mermaidNode("n3", labelCallA, "e", originCallA) +
+			"    n0 --> n1\n" +
+			"    n0 --> n2\n" +
+			"    n1 --> n3\n" +
+			"    n2 --> n3\n" +
+			"    classDef satisfied fill:#9f9,stroke:#090\n",
+	},
+	"mermaid-satisfied": {
+		setup: graphSetup,
+		call: func(mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+			mock.Get(mocks, NewMockIFace[string]).CallA("d")
+		},
+		format: mock.Mermaid,
+		expect: "flowchart TD\n" +
+			mermaidNode("n0", labelCallA, "a", originCallA) +
+			mermaidNode("n1", labelCallB, "b", originCallB) +
+			mermaidNode("n2", labelCallA, "d", originCallA) +
+			mermaidNode("n3", labelCallA, "e", originCallA) +
+			"    n0 --> n1\n" +
+			"    n0 --> n2\n" +
+			"    n1 --> n3\n" +
+			"    n2 --> n3\n" +
+			"    class n0 satisfied\n" +
+			"    class n2 satisfied\n" +
+			"    classDef satisfied fill:#9f9,stroke:#090\n",
+	},
+	"dot-satisfied": {
+		setup: graphSetup,
+		call: func(mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+		},
+		format: mock.DOT,
+		expect: "digraph mocks {\n" +
+			dotNode("n0", labelCallA, "a", originCallA,
+				", style=filled, fillcolor=palegreen") +
+			dotNode("n1", labelCallB, "b", originCallB, "") +
+			dotNode("n2", labelCallA, "d", originCallA, "") +
+			dotNode("n3", labelCallA, "e", originCallA, "") +
+			"    n0 -> n1;\n" +
+			"    n0 -> n2;\n" +
+			"    n1 -> n3;\n" +
+			"    n2 -> n3;\n" +
+			"}\n",
+	},
+}
+
+func TestGraph(t *testing.T) {
+	test.Map(t, graphTestCases).
+		Run(func(t test.Test, param GraphParams) {
+			// Given
+			mocks := mock.NewMocks(&graphT{}).Expect(param.setup)
+			if param.call != nil {
+				param.call(mocks)
+			}
+
+			// When
+			graph := mocks.Graph(param.format)
+
+			// Then
+			assert.Equal(t, param.expect, graph)
+		})
+}
+
+// graphT is a test reporter recording failures and logs for testing failure
+// messages with mock call graphs.
+type graphT struct {
+	mutex    sync.Mutex
+	failures []string
+	logs     []string
+	cleanups []func()
+}
+
+func (*graphT) Helper() {}
+
+func (t *graphT) Errorf(format string, args ...any) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func (t *graphT) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func (t *graphT) Logf(format string, args ...any) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func (t *graphT) Cleanup(cleanup func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.cleanups = append(t.cleanups, cleanup)
+}
+
+// graphOf returns the mock call graph of the given log message.
+func graphOf(log string) string {
+	return strings.TrimPrefix(log, "mock call graph:\n")
+}
+
+// inGoroutine runs the given function in a separate goroutine to allow
+// aborting it via [runtime.Goexit] and waits for its termination.
+func inGoroutine(fn func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	<-done
+}
+
+type GraphOnFailureParams struct {
+	format         mock.GraphFormat
+	call           func(*mock.Mocks)
+	expectFailures int
+	expectGraphs   []string
+}
+
+var graphOnFailureTestCases = map[string]GraphOnFailureParams{
+	"no-graph": {
+		format: mock.NoGraph,
+		call: func(mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+			mocks.Ctrl.Finish()
+		},
+		expectFailures: 2,
+		expectGraphs:   []string{},
+	},
+	"missing-call": {
+		format: mock.Mermaid,
+		call: func(mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+			mocks.Ctrl.Finish()
+		},
+		expectFailures: 2,
+		expectGraphs: []string{"flowchart TD\n" +
+			mermaidNode("n0", labelCallA, "a", originCallA) +
+			mermaidNode("n1", labelCallA, "b", originCallA) +
+			"    n0 --> n1\n" +
+			"    class n0 satisfied\n" +
+			"    classDef satisfied fill:#9f9,stroke:#090\n"},
+	},
+	"unexpected-call": {
+		format: mock.DOT,
+		call: func(mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("b")
+		},
+		expectFailures: 1,
+		expectGraphs: []string{"digraph mocks {\n" +
+			dotNode("n0", labelCallA, "a", originCallA, "") +
+			dotNode("n1", labelCallA, "b", originCallA, "") +
+			"    n0 -> n1;\n" +
+			"}\n"},
+	},
+}
+
+func TestGraphOnFailure(t *testing.T) {
+	test.Map(t, graphOnFailureTestCases).
+		Run(func(t test.Test, param GraphOnFailureParams) {
+			// Given
+			reporter := &graphT{}
+			mocks := mock.NewMocks(reporter,
+				mock.GraphOnFailure(param.format)).
+				Expect(mock.Chain(CallA("a"), CallA("b")))
+
+			// When
+			inGoroutine(func() { param.call(mocks) })
+
+			// Then
+			graphs := make([]string, 0, len(reporter.logs))
+			for _, log := range reporter.logs {
+				graphs = append(graphs, graphOf(log))
+			}
+			assert.Equal(t, param.expectGraphs, graphs)
+			require.Len(t, reporter.failures, param.expectFailures)
+			for _, failure := range reporter.failures {
+				assert.NotContains(t, failure, "mock call graph")
+			}
+		})
+}
+
+func TestGraphFromEnv(t *testing.T) {
+	// Given
+	t.Setenv(mock.GoTestingMockGraphVar, "mermaid")
+	mocks := mock.NewMocks(t).Expect(CallA("a"))
+
+	// When
+	mock.Get(mocks, NewMockIFace[string]).CallA("a")
+
+	// Then
+	assert.Equal(t, "flowchart TD\n"+
+		mermaidNode("n0", labelCallA, "a", originCallA)+
+		"    class n0 satisfied\n"+
+		"    classDef satisfied fill:#9f9,stroke:#090\n",
+		mocks.Graph(mock.Mermaid))
+}
+
+func TestGraphFromEnvReporter(t *testing.T) {
+	// Given
+	t.Setenv(mock.GoTestingMockGraphVar, "mermaid")
+	reporter := &graphT{}
+	mocks := mock.NewMocks(reporter).Expect(CallA("a"))
+
+	// When
+	inGoroutine(func() {
+		mock.Get(mocks, NewMockIFace[string]).CallA("b")
+	})
+
+	// Then
+	assert.Empty(t, reporter.logs)
+	require.Len(t, reporter.failures, 1)
+	assert.NotContains(t, reporter.failures[0], "mock call graph")
+}
+
+func TestGraphFromEnvValidator(t *testing.T) {
+	t.Setenv(mock.GoTestingMockGraphVar, "mermaid")
+	test.Map(t, mockTestCases).RunSeq(testMocks)
+}
@@ -3,6 +3,8 @@ package mock
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
@@ -22,43 +24,53 @@ const (
 	DefaultSkippingTail = 5
 )
 
+// DiffOption common diff configuration function signature. Diff options are
+// accepted by the mock handler as well as by plain diff configurations.
+type DiffOption func(*DiffConfig)
+
+// apply applies the diff option to the diff configuration of the given mock
+// handler.
+func (fn DiffOption) apply(mocks *Mocks) {
+	fn(mocks.diff)
+}
+
 // Context sets the number of context lines to show before and after changes in
 // a diff. The default, 3, means no context lines.
-func Context(context int) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.Context(context)
+func Context(context int) DiffOption {
+	return func(config *DiffConfig) {
+		config.Context(context)
 	}
 }
 
 // FromFile sets the label to use for the "from" side of the diff. Default is
 // `Want`.
-func FromFile(file string) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.FromFile(file)
+func FromFile(file string) DiffOption {
+	return func(config *DiffConfig) {
+		config.FromFile(file)
 	}
 }
 
 // FromDate sets the label to use for the "from" date of the diff. Default is
 // empty.
-func FromDate(date string) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.FromDate(date)
+func FromDate(date string) DiffOption {
+	return func(config *DiffConfig) {
+		config.FromDate(date)
 	}
 }
 
 // ToFile sets the label to use for the "to" side of the diff. Default is
 // `Got`.
-func ToFile(file string) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.ToFile(file)
+func ToFile(file string) DiffOption {
+	return func(config *DiffConfig) {
+		config.ToFile(file)
 	}
 }
 
 // ToDate specifies the label to use for the "to" date of the diff. Default is
 // empty.
-func ToDate(date string) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.ToDate(date)
+func ToDate(date string) DiffOption {
+	return func(config *DiffConfig) {
+		config.ToDate(date)
 	}
 }
 
@@ -66,9 +78,9 @@ func ToDate(date string) ConfigFunc {
 // instance that all top-level functions use set this to a single space by
 // default. If you would like more indentation, you might set this to a tab
 // with `\t` or perhaps two spaces with `  `.
-func Indent(indent string) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.Indent(indent)
+func Indent(indent string) DiffOption {
+	return func(config *DiffConfig) {
+		config.Indent(indent)
 	}
 }
 
@@ -76,18 +88,18 @@ func Indent(indent string) ConfigFunc {
 // structures. The default 0 means there is no limit. Circular data structures
 // are properly detected, so it is not necessary to set this value unless you
 // specifically want to limit deeply nested structures.
-func MaxDepth(maxDepth int) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.MaxDepth(maxDepth)
+func MaxDepth(maxDepth int) DiffOption {
+	return func(config *DiffConfig) {
+		config.MaxDepth(maxDepth)
 	}
 }
 
 // DisableMethods sets whether or not error and `Stringer` interfaces are
 // invoked for types that implement them. Default is true, meaning that these
 // methods will not be invoked.
-func DisableMethods(disable bool) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.DisableMethods(disable)
+func DisableMethods(disable bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.DisableMethods(disable)
 	}
 }
 
@@ -102,26 +114,26 @@ func DisableMethods(disable bool) ConfigFunc {
 // access to the unsafe package, so it will not have any effect when running in
 // environments without access to the unsafe package such as Google App Engine
 // or with the "safe" build tag specified.
-func DisablePointerMethods(disable bool) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.DisablePointerMethods(disable)
+func DisablePointerMethods(disable bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.DisablePointerMethods(disable)
 	}
 }
 
 // DisablePointerAddresses sets whether to disable the printing of pointer
 // addresses. This is useful when diffing data structures in tests.
-func DisablePointerAddresses(disable bool) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.DisablePointerAddresses(disable)
+func DisablePointerAddresses(disable bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.DisablePointerAddresses(disable)
 	}
 }
 
 // DisableCapacities sets whether to disable the printing of capacities for
 // arrays, slices, maps and channels. This is useful when diffing data
 // structures in tests.
-func DisableCapacities(disable bool) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.DisableCapacities(disable)
+func DisableCapacities(disable bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.DisableCapacities(disable)
 	}
 }
 
@@ -133,9 +145,9 @@ func DisableCapacities(disable bool) ConfigFunc {
 //
 // *Note:* This flag does not have any effect if method invocation is disabled
 // via the DisableMethods or DisablePointerMethods options.
-func ContinueOnMethod(enable bool) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.ContinueOnMethod(enable)
+func ContinueOnMethod(enable bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.ContinueOnMethod(enable)
 	}
 }
 
@@ -145,18 +157,18 @@ func ContinueOnMethod(enable bool) ConfigFunc {
 // the error or `Stringer` interfaces (if methods are enabled) are supported,
 // with other types sorted according to the reflect.Value.String() output which
 // guarantees display stability.
-func SortKeys(sort bool) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.SortKeys(sort)
+func SortKeys(sort bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.SortKeys(sort)
 	}
 }
 
 // SpewKeys sets that, as a last resort attempt, map keys should be spewed to
 // strings and sorted by those strings.  This is only considered if keys are
 // sorted (see `SortKeys`).
-func SpewKeys(spew bool) ConfigFunc {
-	return func(mocks *Mocks) {
-		mocks.diff.SpewKeys(spew)
+func SpewKeys(spew bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.SpewKeys(spew)
 	}
 }
 
@@ -167,6 +179,21 @@ type DiffConfig struct {
 	// Tail size after the skipped part string representation.
 	skippingTail int
 
+	// Output mode of the diff.
+	mode DiffMode
+	// Field paths ignored when matching and diffing.
+	ignores []*regexp.Regexp
+	// Struct tag key used to ignore fields when matching and diffing.
+	ignoreTag string
+	// Custom comparers used when matching and diffing.
+	comparers map[reflect.Type]func(a, b any) bool
+	// Interface types of custom comparers in order of registration.
+	ifaces []reflect.Type
+	// Flag whether to highlight changes using ANSI colors.
+	colors bool
+	// Flag whether to normalize JSON strings before diffing.
+	json bool
+
 	// Internal diff lib settings.
 	dlib *difflib.UnifiedDiff
 	// Internal spew config settings.
@@ -176,9 +203,10 @@ type DiffConfig struct {
 }
 
 // NewDiffConfig creates a new matcher configuration instance with default
-// values.
-func NewDiffConfig() *DiffConfig {
-	return &DiffConfig{
+// values adjusted by the given diff options. This allows to use the same diff
+// options for the mock handler and for plain assertions.
+func NewDiffConfig(opts ...DiffOption) *DiffConfig {
+	config := &DiffConfig{
 		skippingSize: DefaultSkippingSize,
 		skippingTail: DefaultSkippingTail,
 		dlib: &difflib.UnifiedDiff{
@@ -200,6 +228,11 @@ func NewDiffConfig() *DiffConfig {
 			SortKeys:                true,
 		},
 	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
 }
 
 // Context sets the number of context lines to show before and after changes in
@@ -321,29 +354,41 @@ func (c *DiffConfig) SpewKeys(spew bool) {
 	c.spew.SpewKeys = spew
 }
 
+// Match returns whether the actual value is equal to the expected value. If
+// custom comparers or ignored fields are set up, or in `DiffPaths` mode,
+// values are compared by walking their fields respecting the custom
+// comparers as well as the ignored field paths and struct tags. Otherwise the
+// values are compared via `reflect.DeepEqual`.
+func (c *DiffConfig) Match(want, got any) bool {
+	if c.custom() && want != nil && got != nil &&
+		reflect.TypeOf(want) == reflect.TypeOf(got) {
+		return len(c.Paths(want, got)) == 0
+	} else if c.mode == DiffPaths && c.diffable(want, got) &&
+		reflect.TypeOf(want).Kind() != reflect.String {
+		return len(c.Paths(want, got)) == 0
+	}
+	return gomock.Eq(want).Matches(got)
+}
+
 // Diff returns a diff of the expected value and the actual value as long as
 // both are of the same type and are a struct, map, slice, array or string.
-// Otherwise it returns an empty string.
+// Otherwise it returns an empty string. In `DiffPaths` mode, the diff lists
+// the differences by field path for all but string values.
 func (c *DiffConfig) Diff(want, got any) string {
-	if want == nil || got == nil {
+	if !c.diffable(want, got) {
 		return ""
 	}
 
 	etype := reflect.TypeOf(want)
-	atype := reflect.TypeOf(got)
-
-	if etype != atype {
+	if c.mode == DiffPaths && etype.Kind() != reflect.String {
+		if diffs := c.Paths(want, got); len(diffs) != 0 {
+			return strings.Join(diffs, "\n") + "\n"
+		}
 		return ""
 	}
 
-	ekind := etype.Kind()
-	if ekind == reflect.Ptr {
-		ekind = etype.Elem().Kind()
-	}
-	if ekind != reflect.Struct && ekind != reflect.Map &&
-		ekind != reflect.Slice && ekind != reflect.Array &&
-		ekind != reflect.String {
-		return ""
+	if c.custom() {
+		got = c.align(want, got)
 	}
 
 	var estr, astr string
@@ -352,6 +397,9 @@ func (c *DiffConfig) Diff(want, got any) string {
 	case reflect.TypeOf(""):
 		estr = reflect.ValueOf(want).String()
 		astr = reflect.ValueOf(got).String()
+		if c.json {
+			estr, astr = normalizeJSON(estr, astr)
+		}
 	case reflect.TypeOf(time.Time{}):
 		estr = c.spewTime.Sdump(want)
 		astr = c.spewTime.Sdump(got)
@@ -360,6 +408,13 @@ func (c *DiffConfig) Diff(want, got any) string {
 		astr = c.spew.Sdump(got)
 	}
 
+	switch c.mode {
+	case DiffInline:
+		return c.inline(estr, astr)
+	case DiffSideBySide:
+		return c.sideBySide(estr, astr)
+	}
+
 	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
 		A: difflib.SplitLines(estr), B: difflib.SplitLines(astr),
 		FromFile: c.dlib.FromFile, FromDate: c.dlib.FromDate,
@@ -367,7 +422,39 @@ func (c *DiffConfig) Diff(want, got any) string {
 		Context: c.dlib.Context,
 	})
 
-	return diff
+	return c.colorize(diff)
+}
+
+// diffable returns whether a diff can be created for the expected and the
+// actual value, i.e. whether both are of the same type and are a struct, map,
+// slice, array or string.
+func (c *DiffConfig) diffable(want, got any) bool {
+	if want == nil || got == nil {
+		return false
+	}
+
+	etype := reflect.TypeOf(want)
+	if etype != reflect.TypeOf(got) {
+		return false
+	}
+
+	ekind := etype.Kind()
+	if ekind == reflect.Ptr {
+		ekind = etype.Elem().Kind()
+	}
+	return ekind == reflect.Struct || ekind == reflect.Map ||
+		ekind == reflect.Slice || ekind == reflect.Array ||
+		ekind == reflect.String
+}
+
+// skip returns a truncated string representation of the given value.
+func (c *DiffConfig) skip(value string) string {
+	if len(value) > c.skippingSize {
+		return value[0:c.skippingSize-c.skippingTail] +
+			"<... skipped ...>" +
+			value[len(value)-c.skippingTail:]
+	}
+	return value
 }
 
 // Equal is an improved `gomock.Matcher` that matches via `reflect.DeepEqual`
@@ -381,8 +468,15 @@ type Equal struct {
 // Equal returns an improved equals matcher showing a detailed diff when there
 // is a mismatch in the expected and actual values.
 func (mocks *Mocks) Equal(want any) *Equal {
+	return mocks.diff.Equal(want)
+}
+
+// Equal returns an improved equals matcher using the diff configuration to
+// show a detailed diff when there is a mismatch in the expected and actual
+// values.
+func (c *DiffConfig) Equal(want any) *Equal {
 	return &Equal{
-		config: mocks.diff,
+		config: c,
 		want:   want,
 		diff:   "",
 	}
@@ -390,7 +484,7 @@ func (mocks *Mocks) Equal(want any) *Equal {
 
 // Matches returns whether the actual value is equal to the expected value.
 func (eq *Equal) Matches(got any) bool {
-	if !gomock.Eq(eq.want).Matches(got) {
+	if !eq.config.Match(eq.want, got) {
 		eq.diff = eq.config.Diff(eq.want, got)
 		return false
 	}
@@ -415,11 +509,5 @@ func (eq *Equal) String() string {
 
 // skip returns a truncated string representation of the given value.
 func (eq *Equal) skip(v any) string {
-	config, value := eq.config, fmt.Sprintf("%#v", v)
-	if len(value) > config.skippingSize {
-		return value[0:config.skippingSize-config.skippingTail] +
-			"<... skipped ...>" +
-			value[len(value)-config.skippingTail:]
-	}
-	return value
+	return eq.config.skip(fmt.Sprintf("%#v", v))
 }
@@ -257,7 +257,7 @@ func TestDiff(t *testing.T) {
 }
 
 type ConfigParams struct {
-	config mock.ConfigFunc
+	config mock.DiffOption
 	access func(mocks *mock.Mocks) any
 	expect any
 }
@@ -469,6 +469,21 @@ func TestConfig(t *testing.T) {
 		})
 }
 
+func TestNewDiffConfig(t *testing.T) {
+	test.Map(t, configTestCases).
+		Run(func(t test.Test, param ConfigParams) {
+			// Given
+			mocks := mock.NewMocks(t)
+
+			// When
+			config := mock.NewDiffConfig(param.config)
+
+			// Then
+			reflect.NewAccessor(mocks).Set(nameDiff, config)
+			assert.Equal(t, param.expect, param.access(mocks))
+		})
+}
+
 type EqualMatchesParams struct {
 	want   any
 	got    any
@@ -3,6 +3,9 @@ package mock
 import (
 	"errors"
 	"fmt"
+	"sort"
+	gosync "sync"
+	"sync/atomic"
 
 	"go.uber.org/mock/gomock"
 
@@ -55,6 +58,9 @@ type (
 	// parallel is the type to signal that mock calls must and will be orders
 	// in a parallel set of mock calls.
 	parallel any
+	// optional is the type to signal that mock calls may be skipped, i.e. the
+	// preceding mock calls are kept as anchors for the succeeding mock calls.
+	optional any
 	// detachHead is the type to signal that the leading mock call must and
 	// will be detached from its predecessor.
 	detachHead any
@@ -72,6 +78,18 @@ type SetupFunc func(*Mocks) any
 // ConfigFunc common mock handler configuration function signature.
 type ConfigFunc func(*Mocks)
 
+// apply applies the config function to the given mock handler.
+func (fn ConfigFunc) apply(mocks *Mocks) {
+	fn(mocks)
+}
+
+// Option common mock handler option, that is either a [ConfigFunc] or a
+// [DiffOption].
+type Option interface {
+	// apply applies the option to the given mock handler.
+	apply(mocks *Mocks)
+}
+
 // Mocks common mock handler.
 type Mocks struct {
 	// The mock controller used.
@@ -82,6 +100,18 @@ type Mocks struct {
 	mocks map[reflect.Type]any
 	// A map of mock key value pairs.
 	args map[any]any
+	// The call recorder in spy mode.
+	spy *spy
+	// The stubbed mocks and methods in stub mode.
+	stubs *stubs
+	// The catch-all mock calls in spy and stub mode.
+	fallbacks *fallbacks
+	// The required alternative mock call setups.
+	decisions *decisions
+	// The default results in spy and stub mode.
+	defaults *defaults
+	// The graph of mock calls and ordering constraints.
+	graph *graph
 
 	// Internal diff settings.
 	diff *DiffConfig
@@ -89,20 +119,25 @@ type Mocks struct {
 
 // NewMocks creates a new mock handler using given test reporter, e.g.
 // [*testing.T], or [test.Test].
-func NewMocks(t gomock.TestReporter, fncalls ...ConfigFunc) *Mocks {
+func NewMocks(t gomock.TestReporter, fncalls ...Option) *Mocks {
 	return (&Mocks{
 		Ctrl:  gomock.NewController(t),
 		wg:    sync.NewLenientWaitGroup(),
 		mocks: map[reflect.Type]any{},
 		args:  map[any]any{},
 		diff:  NewDiffConfig(),
-	}).Config(fncalls...).syncWith(t)
+
+		defaults:  newDefaults(),
+		fallbacks: newFallbacks(),
+		decisions: newDecisions(),
+		graph:     newGraph(),
+	}).Config(graphFromEnv(t)).Config(fncalls...).syncWith(t)
 }
 
-// Config configures the mock handler with given config functions.
-func (mocks *Mocks) Config(fncalls ...ConfigFunc) *Mocks {
+// Config configures the mock handler with given options.
+func (mocks *Mocks) Config(fncalls ...Option) *Mocks {
 	for _, fncall := range fncalls {
-		fncall(mocks)
+		fncall.apply(mocks)
 	}
 	return mocks
 }
@@ -110,7 +145,10 @@ func (mocks *Mocks) Config(fncalls ...ConfigFunc) *Mocks {
 // Expect configures the mock handler to expect the given mock function calls.
 func (mocks *Mocks) Expect(fncalls SetupFunc) *Mocks {
 	if fncalls != nil {
-		Setup(fncalls)(mocks)
+		calls := fncalls(mocks)
+		inOrder([]*Call{}, []detachBoth{calls})
+		captureArgs(calls)
+		mocks.graph.add(calls)
 	}
 	return mocks
 }
@@ -129,6 +167,12 @@ func (mocks *Mocks) Get(creator reflect.Value) any {
 	mock = reflect.ArgOf(creator.Call(
 		reflect.ValuesIn(creator.Type(), mocks.Ctrl))[0])
 	mocks.mocks[key] = mock
+	if mocks.spy != nil {
+		mocks.spyOn(creator, mock)
+	}
+	if mocks.stubs != nil {
+		mocks.stubOn(creator, mock)
+	}
 	return mock
 }
 
@@ -181,23 +225,69 @@ func (mocks *Mocks) SetArgs(args map[any]any) *Mocks {
 	return mocks
 }
 
+// Pending returns the sorted string representations of the pending mock
+// calls, i.e. the mock calls that are expected but not yet satisfied. This
+// can be used to analyze blocked tests.
+func (mocks *Mocks) Pending() []string {
+	ctrl := reflect.ValueOf(mocks.Ctrl).Elem()
+	field, ok := ctrl.Type().FieldByName("expectedCalls")
+	if !ok {
+		return nil
+	}
+
+	pending := []string{}
+	mocks.withController(func() {
+		calls := reflect.ValueOf(reflect.FieldOf(ctrl, field.Index[0])).
+			MethodByName("Failures").Call(nil)[0]
+		for index := range calls.Len() {
+			pending = append(pending,
+				fmt.Sprint(calls.Index(index).Interface()))
+		}
+	})
+	sort.Strings(pending)
+	return pending
+}
+
+// withController calls the given function while holding the lock of the mock
+// controller, that is guarding the state of the mock calls while consuming
+// them concurrently.
+func (mocks *Mocks) withController(call func()) {
+	ctrl := reflect.ValueOf(mocks.Ctrl).Elem()
+	if field, ok := ctrl.Type().FieldByName("mu"); ok {
+		mutex, ok := reflect.FieldValueOf(ctrl, field.Index[0]).
+			Addr().Interface().(*gosync.Mutex)
+		if ok {
+			mutex.Lock()
+			defer mutex.Unlock()
+		}
+	}
+	call()
+}
+
 // syncWith used to synchronize the wait group of the mock setup with the wait
-// group of the given test reporter. This function is called automatically on
-// mock creation and therefore does not need to be called on the same reporter
-// again.
+// group of the given test reporter. It also registers the pending mock calls
+// to be tracked by the test reporter. This function is called automatically
+// on mock creation and therefore does not need to be called on the same
+// reporter again.
 func (mocks *Mocks) syncWith(t gomock.TestReporter) *Mocks {
 	if s, ok := t.(sync.Synchronizer); ok {
 		s.WaitGroup(mocks.wg)
 	}
+	if s, ok := t.(sync.Tracker); ok {
+		s.Track(mocks.Pending)
+	}
 	return mocks
 }
 
 // Wait waits for all mock calls registered via [Call], [Do], [Return],
 // [Panic], and [Times] to be consumed before testing can continue. This method
 // implements the [sync.WaitGroup] interface to support testing of detached
-// *goroutines* in an isolated [test](../test) environment.
+// *goroutines* in an isolated [test](../test) environment. If no branch of an
+// alternative mock call setup created via [OneOf] is taken after all mock
+// calls are consumed, a [ErrBranchNotTaken] failure is reported.
 func (mocks *Mocks) Wait() {
 	mocks.wg.Wait()
+	mocks.decisions.check(mocks.Ctrl.T)
 }
 
 // Add adds the given delta on the wait group to register the expected or
@@ -298,57 +388,97 @@ func (mocks *Mocks) notify(
 			mocks.Ctrl.T.Helper()
 
 			defer mocks.wg.Done()
+			out := args
 			if call != nil {
-				args = call(reflect.ArgsOf(in...)...)
+				out = call(reflect.ArgsOf(in...)...)
 			}
 
-			return reflect.ValuesOut(ftype, lenient, args...)
+			return reflect.ValuesOut(ftype, lenient, out...)
 		})
 
 	return notify
 }
 
-// TODO: Reconsider approach - complex signature. Test setup look as follows:
-//
-//	func CallBX(input string, output string) mock.SetupFunc {
-//		return mock.Mock(NewMockIFace, func(mock *MockIFace) *gomock.Call {
-//			return mock.EXPECT().CallB(input).Return(output)
-//		})
-//	}
-//
-// Mock defines an advanced mock setup function for exactly one mock call setup
-// by resolving the singleton mock instance and handing it over to the provided
-// function for calling the mock method and providing the return values. The
-// created function automatically sets up the wait group for advanced testing
-// strategies.
-//
-// func Mock[T any](
-// 	creator func(*Controller) *T, call func(*T) *gomock.Call,
-// ) SetupFunc {
-// 	return func(mocks *Mocks) any {
-// 		call := call(Get(mocks, creator))
-// 		value := reflect.ValueOf(call).Elem()
-// 		field := value.FieldByName("methodType")
-// 		ftype := *(*reflect.Type)(unsafe.Pointer(field.UnsafeAddr()))
-// 		btype := reflect.BaseFuncOf(ftype, 0, ftype.NumOut())
-// 		return call.Do(mocks.notify(btype, nil))
-// 	}
-// }
-
 // Get resolves the actual mock from the mock handler by providing the
 // constructor function generated by `gomock` to create a new mock.
 func Get[T any](mocks *Mocks, creator func(*Controller) *T) *T {
 	return mocks.Get(reflect.ValueOf(creator)).(*T)
 }
 
-// TODO: decide on mock strategy.
+// Expect creates a mock setup function for exactly one mock call setup. It
+// resolves the singleton mock instance via [Get] and hands over the typed mock
+// recorder to the provided function for calling the mock method and providing
+// the results. The function only ensures at compile time that the mock and the
+// recorder match. The results provided via `Return`, `Do`, and `DoAndReturn`
+// are only checked at compile time, if the mocks are generated with typed call
+// wrappers, e.g. via `mockgen -typed`:
+//
+// ```go
 //
-// Expect resolves the mock recorder from the mock handler by providing the
-// constructor function generated by `gomock`.
-// func Expect[T any](mocks *Mocks, creator func(*Controller) any) T {
-// 	return reflect.ValueOf(mocks.Get(creator)).
-// 		MethodByName("EXPECT").Call(nil)[0].Interface().(T)
-// }
+//	func CallB(input string, output string) mock.SetupFunc {
+//		return mock.Expect(NewMockIFace,
+//			func(mock *MockIFaceMockRecorder) *MockIFaceCallBCall {
+//				return mock.CallB(input).Return(output)
+//			})
+//	}
+//
+// ```
+//
+// The function automatically registers the minimum number of expected calls,
+// e.g. as set up via `Times`, on the wait group and consumes them when the
+// calls are executed. Since the call type is not constrained, the provided
+// function must return a [gomock.Call] or a typed call embedding it, otherwise
+// the setup panics at runtime.
+func Expect[M interface{ EXPECT() R }, R any, C any](
+	creator func(*Controller) M, call func(R) C,
+) SetupFunc {
+	return func(mocks *Mocks) any {
+		mock := mocks.Get(reflect.ValueOf(creator)).(M)
+		ecall := callOf(call(mock.EXPECT()))
+
+		calls := int32(minCallsOf(ecall))
+		mocks.wg.Add(int(calls))
+
+		return onCall(ecall, func() {
+			if atomic.AddInt32(&calls, -1) >= 0 {
+				mocks.wg.Done()
+			}
+		})
+	}
+}
+
+// onCall adds the given notification function as action to the given mock
+// call without changing the results of the mock call.
+func onCall(call *Call, notify func()) *Call {
+	value := reflect.ValueOf(call).Elem()
+	mtype := reflect.FieldByName(value, "methodType").(reflect.Type)
+	btype := reflect.BaseFuncOf(mtype, 0, mtype.NumOut())
+	return call.Do(reflect.MakeFuncOf(btype,
+		func([]reflect.Value) []reflect.Value {
+			notify()
+			return nil
+		}))
+}
+
+// callOf returns the mock call of the given call, that is either a
+// [gomock.Call] or a typed call embedding a [gomock.Call], as generated by
+// `mockgen -typed`.
+func callOf(call any) *Call {
+	if ecall, ok := call.(*Call); ok {
+		return ecall
+	}
+
+	value := reflect.ValueOf(call)
+	if value.Kind() == reflect.Ptr && !value.IsNil() &&
+		value.Elem().Kind() == reflect.Struct {
+		if field := value.Elem().FieldByName("Call"); field.IsValid() {
+			if ecall, ok := field.Interface().(*Call); ok && ecall != nil {
+				return ecall
+			}
+		}
+	}
+	panic(NewErrNoCall(call))
+}
 
 // Setup creates only a lazily ordered set of mock calls that is detached from
 // the parent setup by returning no calls for chaining. The mock calls created
@@ -358,7 +488,9 @@ func Get[T any](mocks *Mocks, creator func(*Controller) *T) *T {
 func Setup(fncalls ...func(*Mocks) any) func(*Mocks) any {
 	return func(mocks *Mocks) any {
 		for _, fncall := range fncalls {
-			inOrder([]*Call{}, []detachBoth{fncall(mocks)})
+			calls := fncall(mocks)
+			inOrder([]*Call{}, []detachBoth{calls})
+			captureArgs(calls)
 		}
 		return nil
 	}
@@ -435,6 +567,9 @@ func Sub(from, to int, fncall func(*Mocks) any) func(*Mocks) any {
 		case []parallel:
 			inOrder([]*Call{}, calls)
 			return GetSubSlice(from, to, calls)
+		case []optional:
+			inOrder([]*Call{}, calls)
+			return GetSubSlice(from, to, calls)
 		case []detachBoth:
 			panic(NewErrDetachNotAllowed(Both))
 		case []detachHead:
@@ -493,6 +628,8 @@ func chainCalls(calls []chain, more ...any) []chain {
 			calls = append(calls, call...)
 		case []parallel:
 			calls = append(calls, call)
+		case []optional:
+			calls = append(calls, call)
 		case []detachBoth:
 			calls = append(calls, call)
 		case []detachHead:
@@ -518,6 +655,8 @@ func inOrder(anchors []*Call, call any) []*Call {
 		return inOrderParallel(anchors, call)
 	case []chain:
 		return inOrderChain(anchors, call)
+	case []optional:
+		return inOrderOptional(anchors, call)
 	case []detachBoth:
 		return inOrderDetachBoth(anchors, call)
 	case []detachHead:
@@ -564,6 +703,19 @@ func inOrderParallel(anchors []*Call, calls []parallel) []*Call {
 	return nanchors
 }
 
+// inOrderOptional creates an optional order of the given mock calls using the
+// given anchors as predecessor and returns the given anchors together with all
+// optional mock calls as next anchors, since the mock calls may be skipped, but
+// must not be called after any succeeding mock call.
+func inOrderOptional(anchors []*Call, calls []optional) []*Call {
+	nanchors := append([]*Call{}, anchors...)
+	for _, call := range calls {
+		inOrder(anchors, call)
+		nanchors = append(nanchors, callsOf(call)...)
+	}
+	return nanchors
+}
+
 // inOrderDetachBoth creates a detached set of mock calls without using the
 // anchors as predecessor nor returning the last mock calls as next anchor.
 func inOrderDetachBoth(anchors []*Call, calls []detachBoth) []*Call {
@@ -599,6 +751,9 @@ var (
 
 	// ErrModeNotSupported type for unsupported mode errors.
 	ErrModeNotSupported = errors.New("mode not supported")
+
+	// ErrBranchNotTaken type for mock calls of branches not taken.
+	ErrBranchNotTaken = errors.New("branch not taken")
 )
 
 // NewErrNoCall creates an error with given call type to panic on incorrect
@@ -621,3 +776,18 @@ func NewErrDetachNotAllowed(mode DetachMode) error {
 	return fmt.Errorf("%w [mode: %v] not supported in sub",
 		ErrModeNotSupported, mode)
 }
+
+// NewErrStubNotSupported creates an error that the given stub target is not
+// supported.
+func NewErrStubNotSupported(target any) error {
+	return fmt.Errorf("%w [type: %v] must be mock constructor or "+
+		"interface method", ErrTypeNotSupported, reflect.TypeOf(target))
+}
+
+// NewErrBranchNotTaken creates an error that a mock call of the branch with
+// the given index was consumed, while the branch with the given taken index
+// was already taken. Both indexes are -1, if no branch was taken at all.
+func NewErrBranchNotTaken(index, taken int) error {
+	return fmt.Errorf("%w [branch: %d, taken: %d]",
+		ErrBranchNotTaken, index, taken)
+}
@@ -39,12 +39,6 @@ func CallB[T any](input T, output T) mock.SetupFunc {
 	}
 }
 
-// func CallBX(input string, output string) mock.SetupFunc {
-// 	return mock.Mock(NewMockIFace, func(mock *MockIFace) *gomock.Call {
-// 		return mock.EXPECT().CallB(input).Return(output)
-// 	})
-// }
-
 func NoCall[T any]() mock.SetupFunc {
 	return func(mocks *mock.Mocks) any {
 		return mock.Get(mocks, NewMockIFace[T]).EXPECT()
@@ -100,7 +94,7 @@ var mockTestCases = map[string]mockParams{
 	},
 	"single-mock-with-unexpected-call": {
 		misses: test.UnexpectedCall(NewMockIFace[string],
-			"CallA", path.Join(SourceDir, "mocks_test.go:105"), "ok"),
+			"CallA", path.Join(SourceDir, "mocks_test.go:99"), "ok"),
 		call: func(_ test.Test, mocks *mock.Mocks) {
 			mock.Get(mocks, NewMockIFace[string]).CallA("ok")
 		},
@@ -110,7 +104,7 @@ var mockTestCases = map[string]mockParams{
 			CallA("ok"),
 		),
 		misses: test.ConsumedCall(NewMockIFace[string],
-			"CallA", path.Join(SourceDir, "mocks_test.go:117"),
+			"CallA", path.Join(SourceDir, "mocks_test.go:111"),
 			path.Join(SourceDir, "mocks_test.go:28"), "ok"),
 		call: func(_ test.Test, mocks *mock.Mocks) {
 			mock.Get(mocks, NewMockIFace[string]).CallA("ok")
@@ -143,28 +137,31 @@ var mockTestCases = map[string]mockParams{
 }
 
 func TestMocks(t *testing.T) {
-	test.Map(t, mockTestCases).
-		Run(func(t test.Test, param mockParams) {
-			// Given
-			mocks := mock.NewMocks(t)
+	test.Map(t, mockTestCases).Run(testMocks)
+}
 
-			// When
-			test.InRun(test.Success, func(tt test.Test) {
-				// Given
-				imocks := mock.NewMocks(tt)
-				if param.misses != nil {
-					mocks.Expect(param.misses(tt, imocks))
-				}
-				imocks.Expect(param.setup)
+// testMocks runs the given mock test case validating the mock controller
+// failures using a mocked test reporter.
+func testMocks(t test.Test, param mockParams) {
+	// Given
+	mocks := mock.NewMocks(t)
 
-				// Connect the mock controller directly to the isolated parent
-				// test environment to capture the mock controller failure.
-				imocks.Ctrl.T = t
+	// When
+	test.InRun(test.Success, func(tt test.Test) {
+		// Given
+		imocks := mock.NewMocks(tt)
+		if param.misses != nil {
+			mocks.Expect(param.misses(tt, imocks))
+		}
+		imocks.Expect(param.setup)
 
-				// When
-				param.call(tt, imocks)
-			})(t)
-		})
+		// Connect the mock controller directly to the isolated parent test
+		// environment to capture the mock controller failure.
+		imocks.Ctrl.T = t
+
+		// When
+		param.call(tt, imocks)
+	})(t)
 }
 
 func TestMockArgs(t *testing.T) {
@@ -613,6 +610,14 @@ func TestDetach(t *testing.T) {
 	})
 }
 
+func TestDetachMode(t *testing.T) {
+	cases := test.EnumTestCases[mock.DetachMode](nil)
+	// Detach mode `None` has no string representation, yet.
+	delete(cases, "none")
+
+	test.Map(t, cases).Run(test.Enum[mock.DetachMode])
+}
+
 type PanicParams struct {
 	setup       mock.SetupFunc
 	expectError error
@@ -651,6 +656,21 @@ var panicTestCases = map[string]PanicParams{
 		setup:       mock.Sub(0, 0, mock.Detach(mock.Both, NoCall[string]())),
 		expectError: mock.NewErrDetachNotAllowed(mock.Both),
 	},
+	"expect": {
+		setup: mock.Expect(NewMockIFace[string],
+			func(mock *MockIFaceMockRecorder[string]) any {
+				return mock
+			}),
+		expectError: mock.NewErrNoCall(NewMockIFace[string](nil).EXPECT()),
+	},
+	"expect-nil": {
+		setup: mock.Expect(NewMockTypedIFace[string], func(
+			*MockTypedIFaceMockRecorder[string],
+		) *MockTypedIFaceCallACall[string] {
+			return nil
+		}),
+		expectError: mock.NewErrNoCall((*MockTypedIFaceCallACall[string])(nil)),
+	},
 }
 
 func TestPanic(t *testing.T) {
@@ -666,6 +686,89 @@ func TestPanic(t *testing.T) {
 	})
 }
 
+func ExpectA[T any](input T) mock.SetupFunc {
+	return mock.Expect(NewMockTypedIFace[T],
+		func(mock *MockTypedIFaceMockRecorder[T]) *MockTypedIFaceCallACall[T] {
+			return mock.CallA(input)
+		})
+}
+
+func ExpectB[T any](input T, output T, times int) mock.SetupFunc {
+	return mock.Expect(NewMockTypedIFace[T],
+		func(mock *MockTypedIFaceMockRecorder[T]) *MockTypedIFaceCallBCall[T] {
+			call := mock.CallB(input).Return(output)
+			call.Times(times)
+			return call
+		})
+}
+
+func ExpectC(input any) mock.SetupFunc {
+	return mock.Expect(NewMockXFace,
+		func(mock *MockXFaceMockRecorder) *gomock.Call {
+			return mock.CallC(input).AnyTimes()
+		})
+}
+
+type ExpectParams struct {
+	setup mock.SetupFunc
+	call  func(test.Test, *mock.Mocks)
+}
+
+var expectTestCases = map[string]ExpectParams{
+	"typed-call": {
+		setup: ExpectA("a"),
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockTypedIFace[string]).CallA("a")
+		},
+	},
+	"typed-call-return": {
+		setup: ExpectB("a", "b", 1),
+		call: func(t test.Test, mocks *mock.Mocks) {
+			assert.Equal(t, "b",
+				mock.Get(mocks, NewMockTypedIFace[string]).CallB("a"))
+		},
+	},
+	"typed-call-times": {
+		setup: ExpectB(1, 2, 3),
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			for range 3 {
+				go mock.Get(mocks, NewMockTypedIFace[int]).CallB(1)
+			}
+		},
+	},
+	"untyped-call-any-times": {
+		setup: ExpectC("c"),
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockXFace).CallC("c")
+			mock.Get(mocks, NewMockXFace).CallC("c")
+		},
+	},
+	"mixed-calls": {
+		setup: mock.Chain(ExpectA("a"), CallA("b"), ExpectB("c", "d", 1)),
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockTypedIFace[string]).CallA("a")
+			go func() {
+				mock.Get(mocks, NewMockIFace[string]).CallA("b")
+				mock.Get(mocks, NewMockTypedIFace[string]).CallB("c")
+			}()
+		},
+	},
+}
+
+func TestExpect(t *testing.T) {
+	test.Map(t, expectTestCases).
+		Run(func(t test.Test, param ExpectParams) {
+			// Given
+			mocks := mock.NewMocks(t).Expect(param.setup)
+
+			// When
+			param.call(t, mocks)
+
+			// Then
+			mocks.Wait()
+		})
+}
+
 type getSubSliceParams struct {
 	slice       []any
 	from, to    int
@@ -930,6 +1033,35 @@ func TestFuncPanic(t *testing.T) {
 		})
 }
 
+func TestFuncCallConcurrent(t *testing.T) {
+	test.Map(t, funcTestCases).
+		Run(func(t test.Test, param funcParams) {
+			// Given
+			mocks := MockSetup(t, param.setup)
+			call := mocks.Call(param.call, func(...any) []any {
+				return param.result
+			})
+			mocks.Add(1)
+			ftype := reflect.TypeOf(call)
+			in := reflect.ValuesIn(ftype, make([]any, ftype.NumIn())...)
+
+			// When
+			results := make(chan []any, 2)
+			for range cap(results) {
+				go func() {
+					results <- reflect.ArgsOf(
+						reflect.ValueOf(call).Call(in)...)
+				}()
+			}
+
+			// Then
+			for range cap(results) {
+				assert.Equal(t, param.expect, <-results)
+			}
+			mocks.Wait()
+		})
+}
+
 type failureParams struct {
 	expect test.Expect
 	test   test.Func
@@ -1009,3 +1141,162 @@ func TestFuncWait(t *testing.T) {
 			mocks.Wait()
 		})
 }
+
+type PendingParams struct {
+	setup         mock.SetupFunc
+	before        func(mocks *mock.Mocks)
+	after         func(mocks *mock.Mocks)
+	expectPending []string
+}
+
+var pendingTestCases = map[string]PendingParams{
+	"no-calls": {
+		expectPending: []string{},
+	},
+	"consumed-call": {
+		setup: CallA("a"),
+		before: func(mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+		},
+		expectPending: []string{},
+	},
+	"pending-call": {
+		setup: CallA("a"),
+		after: func(mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+		},
+		expectPending: []string{
+			"*mock_test.MockIFace[string].CallA(string(\"a\"))",
+		},
+	},
+	"pending-calls": {
+		setup: mock.Setup(CallB("b", "c"), CallA("a"), CallC("c")),
+		after: func(mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+			mock.Get(mocks, NewMockIFace[string]).CallB("b")
+			mock.Get(mocks, NewMockXFace).CallC("c")
+		},
+		expectPending: []string{
+			"*mock_test.MockIFace[string].CallA(string(\"a\"))",
+			"*mock_test.MockIFace[string].CallB(string(\"b\"))",
+			"*mock_test.MockXFace.CallC(string(\"c\"))",
+		},
+	},
+}
+
+func TestPending(t *testing.T) {
+	test.Map(t, pendingTestCases).
+		Run(func(t test.Test, param PendingParams) {
+			// Given
+			mocks := mock.NewMocks(t).Expect(param.setup)
+			if param.before != nil {
+				param.before(mocks)
+			}
+
+			// When
+			pending := mocks.Pending()
+
+			// Then
+			for index, call := range pending {
+				pending[index], _, _ = strings.Cut(call, " "+SourceDir)
+			}
+			assert.Equal(t, param.expectPending, pending)
+			if param.after != nil {
+				param.after(mocks)
+			}
+		})
+}
+
+// delegateIFace is a real implementation of IFace to delegate calls to.
+type delegateIFace struct {
+	calls []string
+}
+
+func (d *delegateIFace) CallA(input string) {
+	d.calls = append(d.calls, input)
+}
+
+func (d *delegateIFace) CallB(input string) string {
+	d.calls = append(d.calls, input)
+	return "delegate-" + input
+}
+
+type DelegateParams struct {
+	setup       mock.SetupFunc
+	delegate    bool
+	call        func(test.Test, *MockIFace[string])
+	expect      test.Expect
+	expectCalls []string
+}
+
+var delegateTestCases = map[string]DelegateParams{
+	"delegate-calls": {
+		delegate: true,
+		call: func(t test.Test, iface *MockIFace[string]) {
+			iface.CallA("a")
+			assert.Equal(t, "delegate-b", iface.CallB("b"))
+		},
+		expect:      test.Success,
+		expectCalls: []string{"a", "b"},
+	},
+	"expect-before-delegate": {
+		setup:    CallB("a", "x"),
+		delegate: true,
+		call: func(t test.Test, iface *MockIFace[string]) {
+			assert.Equal(t, "delegate-b", iface.CallB("b"))
+			assert.Equal(t, "x", iface.CallB("a"))
+			assert.Equal(t, "delegate-a", iface.CallB("a"))
+		},
+		expect:      test.Success,
+		expectCalls: []string{"b", "a"},
+	},
+	"expect-after-delegate": {
+		delegate: true,
+		call: func(t test.Test, iface *MockIFace[string]) {
+			iface.EXPECT().CallB("a").Return("x")
+
+			assert.Equal(t, "delegate-b", iface.CallB("b"))
+			assert.Equal(t, "x", iface.CallB("a"))
+			assert.Equal(t, "delegate-a", iface.CallB("a"))
+		},
+		expect:      test.Success,
+		expectCalls: []string{"b", "a"},
+	},
+	"expect-missing-call": {
+		setup:    CallA("a"),
+		delegate: true,
+		call: func(_ test.Test, iface *MockIFace[string]) {
+			iface.CallA("b")
+		},
+		expect:      test.Failure,
+		expectCalls: []string{"b"},
+	},
+	"no-delegate": {
+		call: func(_ test.Test, iface *MockIFace[string]) {
+			iface.CallA("a")
+		},
+		expect: test.Failure,
+	},
+}
+
+func TestDelegate(t *testing.T) {
+	test.Map(t, delegateTestCases).
+		Run(func(t test.Test, param DelegateParams) {
+			// Given
+			mocks := mock.NewMocks(t).Expect(param.setup)
+			iface := mock.Get(mocks, NewMockIFace[string])
+			delegate := &delegateIFace{}
+			mock.Delegate(mocks.Ctrl, iface, func() any {
+				if param.delegate {
+					return delegate
+				}
+				return nil
+			})
+
+			// When
+			param.call(t, iface)
+
+			// Then
+			assert.Equal(t, param.expectCalls, delegate.calls)
+		})
+}
@@ -0,0 +1,271 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	ireflect "github.com/tkrop/go-testing/internal/reflect"
+)
+
+// DiffMode defines the output mode of a diff created by [DiffConfig.Diff].
+type DiffMode int
+
+const (
+	// DiffUnified creates a unified text diff of the dumped values (default).
+	DiffUnified DiffMode = iota
+	// DiffPaths creates a list of differences each reported by its field path
+	// and its expected and actual value, e.g. `.Items["x"].Price: want 10,
+	// got 12`.
+	DiffPaths
+	// DiffInline creates an inline word- and character-level diff marking
+	// removed parts as `[-removed-]` and added parts as `{+added+}`.
+	DiffInline
+	// DiffSideBySide creates a line-based side-by-side diff showing the
+	// expected value on the left and the actual value on the right.
+	DiffSideBySide
+)
+
+// Mode sets the output mode of the diff. The default, `DiffUnified`, creates
+// a unified text diff of the dumped values, `DiffPaths` reports each
+// difference by its field path, `DiffInline` marks changed words inline, and
+// `DiffSideBySide` shows both values in two columns.
+func Mode(mode DiffMode) DiffOption {
+	return func(config *DiffConfig) {
+		config.Mode(mode)
+	}
+}
+
+// Ignore sets up field paths, e.g. `.Orders[*].ID`, that are ignored when
+// matching values and when rendering the diff. The wildcard `[*]` matches any
+// slice, array, or map index.
+func Ignore(paths ...string) DiffOption {
+	return func(config *DiffConfig) {
+		config.Ignore(paths...)
+	}
+}
+
+// IgnoreTag sets up the struct tag key used to ignore struct fields when
+// matching values and when rendering the diff. Fields are ignored if the tag
+// value is `-`, e.g. `diff:"-"` for the key `diff`.
+func IgnoreTag(key string) DiffOption {
+	return func(config *DiffConfig) {
+		config.IgnoreTag(key)
+	}
+}
+
+// Mode sets the output mode of the diff. The default, `DiffUnified`, creates
+// a unified text diff of the dumped values, `DiffPaths` reports each
+// difference by its field path, `DiffInline` marks changed words inline, and
+// `DiffSideBySide` shows both values in two columns.
+func (c *DiffConfig) Mode(mode DiffMode) {
+	c.mode = mode
+}
+
+// Ignore sets up field paths, e.g. `.Orders[*].ID`, that are ignored when
+// matching values and when rendering the diff. The wildcard `[*]` matches any
+// slice, array, or map index.
+func (c *DiffConfig) Ignore(paths ...string) {
+	for _, path := range paths {
+		c.ignores = append(c.ignores, regexp.MustCompile("^"+strings.ReplaceAll(
+			regexp.QuoteMeta(path), `\[\*\]`, `\[[^\]]*\]`)+"$"))
+	}
+}
+
+// IgnoreTag sets up the struct tag key used to ignore struct fields when
+// matching values and when rendering the diff. Fields are ignored if the tag
+// value is `-`, e.g. `diff:"-"` for the key `diff`.
+func (c *DiffConfig) IgnoreTag(key string) {
+	c.ignoreTag = key
+}
+
+// Paths returns the differences of the expected value and the actual value
+// each reported by its field path and its expected and actual value. The
+// values are walked using reflection including unexported fields.
+func (c *DiffConfig) Paths(want, got any) []string {
+	walker := &pathWalker{config: c, visited: map[[2]uintptr]bool{}}
+	walker.walk("", reflect.ValueOf(want), reflect.ValueOf(got))
+	return walker.diffs
+}
+
+// pathWalker is walking two values in parallel collecting the differences.
+type pathWalker struct {
+	config  *DiffConfig
+	visited map[[2]uintptr]bool
+	diffs   []string
+}
+
+// walk compares the expected and actual value at the given field path and
+// descends into their nested values.
+func (w *pathWalker) walk(path string, want, got reflect.Value) {
+	if w.ignored(path) {
+		return
+	} else if !want.IsValid() || !got.IsValid() {
+		if want.IsValid() || got.IsValid() {
+			w.report(path, want, got)
+		}
+		return
+	} else if want.Type() != got.Type() {
+		w.report(path, want, got)
+		return
+	} else if compare, ok := w.config.comparer(want.Type()); ok {
+		if !compare(want.Interface(), got.Interface()) {
+			w.report(path, want, got)
+		}
+		return
+	}
+
+	switch want.Kind() {
+	case reflect.Ptr:
+		if want.IsNil() || got.IsNil() || want.Pointer() == got.Pointer() {
+			if want.IsNil() != got.IsNil() {
+				w.report(path, want, got)
+			}
+			return
+		}
+		key := [2]uintptr{want.Pointer(), got.Pointer()}
+		if w.visited[key] {
+			return
+		}
+		w.visited[key] = true
+		w.walk(path, want.Elem(), got.Elem())
+
+	case reflect.Interface:
+		if want.IsNil() || got.IsNil() {
+			if want.IsNil() != got.IsNil() {
+				w.report(path, want, got)
+			}
+			return
+		}
+		w.walk(path, want.Elem(), got.Elem())
+
+	case reflect.Struct:
+		if want.Type() == reflect.TypeOf(time.Time{}) {
+			w.compare(path, want, got)
+			return
+		}
+		for index := 0; index < want.NumField(); index++ {
+			field := want.Type().Field(index)
+			if w.config.ignoreTag != "" &&
+				field.Tag.Get(w.config.ignoreTag) == "-" {
+				continue
+			}
+			w.walk(path+"."+field.Name,
+				ireflect.FieldValueOf(want, index),
+				ireflect.FieldValueOf(got, index))
+		}
+
+	case reflect.Slice:
+		if want.IsNil() != got.IsNil() {
+			w.report(path, want, got)
+			return
+		}
+		w.walkIndex(path, want, got)
+
+	case reflect.Array:
+		w.walkIndex(path, want, got)
+
+	case reflect.Map:
+		if want.IsNil() != got.IsNil() {
+			w.report(path, want, got)
+			return
+		}
+		w.walkMap(path, want, got)
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if want.Pointer() != got.Pointer() {
+			w.report(path, want, got)
+		}
+
+	default:
+		w.compare(path, want, got)
+	}
+}
+
+// walkIndex compares the elements of the expected and the actual slice or
+// array value reporting missing and additional elements.
+func (w *pathWalker) walkIndex(path string, want, got reflect.Value) {
+	for index := 0; index < max(want.Len(), got.Len()); index++ {
+		ipath := indexKey(path, index)
+		switch {
+		case index >= want.Len():
+			w.walk(ipath, reflect.Value{}, got.Index(index))
+		case index >= got.Len():
+			w.walk(ipath, want.Index(index), reflect.Value{})
+		default:
+			w.walk(ipath, want.Index(index), got.Index(index))
+		}
+	}
+}
+
+// walkMap compares the entries of the expected and the actual map value in
+// order of their formatted keys reporting missing and additional entries.
+func (w *pathWalker) walkMap(path string, want, got reflect.Value) {
+	keys := map[string]reflect.Value{}
+	for _, key := range append(want.MapKeys(), got.MapKeys()...) {
+		keys[mapKey(key)] = key
+	}
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		w.walk(path+"["+name+"]",
+			want.MapIndex(keys[name]), got.MapIndex(keys[name]))
+	}
+}
+
+// compare compares the expected and the actual leaf value via deep equality
+// and reports a difference.
+func (w *pathWalker) compare(path string, want, got reflect.Value) {
+	if !reflect.DeepEqual(want.Interface(), got.Interface()) {
+		w.report(path, want, got)
+	}
+}
+
+// report records a difference of the expected and the actual value at the
+// given field path.
+func (w *pathWalker) report(path string, want, got reflect.Value) {
+	if path == "" {
+		path = "."
+	}
+	w.diffs = append(w.diffs, fmt.Sprintf("%s: want %s, got %s",
+		path, w.format(want), w.format(got)))
+}
+
+// format returns a truncated string representation of the given value.
+func (w *pathWalker) format(value reflect.Value) string {
+	if !value.IsValid() {
+		return "<missing>"
+	} else if value.Type() == reflect.TypeOf(time.Time{}) {
+		return w.config.skip(fmt.Sprintf("%v", value.Interface()))
+	}
+	return w.config.skip(fmt.Sprintf("%#v", value.Interface()))
+}
+
+// indexKey returns the field path of the slice or array element with the
+// given index.
+func indexKey(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+// mapKey returns the formatted map key used in field paths.
+func mapKey(key reflect.Value) string {
+	return fmt.Sprintf("%#v", key.Interface())
+}
+
+// ignored returns whether the given field path is ignored.
+func (w *pathWalker) ignored(path string) bool {
+	for _, ignore := range w.config.ignores {
+		if ignore.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package mock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+type Item struct {
+	Price int
+	note  string
+}
+
+type Order struct {
+	ID      string `diff:"-"`
+	Items   map[string]Item
+	Tags    []string
+	Created time.Time
+	Next    *Order
+	Value   any
+}
+
+type Orders struct {
+	Orders []Order
+}
+
+type PathsParams struct {
+	config []mock.DiffOption
+	want   any
+	got    any
+	expect []string
+}
+
+var pathsTestCases = map[string]PathsParams{
+	"equal": {
+		want: Orders{Orders: []Order{{Items: map[string]Item{"x": {}}}}},
+		got:  Orders{Orders: []Order{{Items: map[string]Item{"x": {}}}}},
+	},
+	"field-value": {
+		want: Orders{Orders: []Order{{}, {}, {Items: map[string]Item{
+			"x": {Price: 10},
+		}}}},
+		got: Orders{Orders: []Order{{}, {}, {Items: map[string]Item{
+			"x": {Price: 12},
+		}}}},
+		expect: []string{`.Orders[2].Items["x"].Price: want 10, got 12`},
+	},
+	"unexported-field": {
+		want:   Item{note: "a"},
+		got:    Item{note: "b"},
+		expect: []string{`.note: want "a", got "b"`},
+	},
+	"slice-length": {
+		want: Order{Tags: []string{"a"}},
+		got:  Order{Tags: []string{"a", "b"}},
+		expect: []string{
+			`.Tags[1]: want <missing>, got "b"`,
+		},
+	},
+	"slice-nil": {
+		want:   Order{Tags: []string{}},
+		got:    Order{},
+		expect: []string{`.Tags: want []string{}, got []string(nil)`},
+	},
+	"map-keys": {
+		want: Order{Items: map[string]Item{"a": {}, "b": {}}},
+		got:  Order{Items: map[string]Item{"b": {}, "c": {}}},
+		expect: []string{
+			`.Items["a"]: want mock_test.Item{Price:0, note:""}, got <missing>`,
+			`.Items["c"]: want <missing>, got mock_test.Item{Price:0, note:""}`,
+		},
+	},
+	"pointer-nil": {
+		want: Order{Next: &Order{}},
+		got:  Order{},
+		expect: []string{
+			`.Next: want &mock_test.Order{ID:"", Items:map[string]mock` +
+				`<... skipped ...>nil)}, ` +
+				`got (*mock_test.Order)(nil)`,
+		},
+	},
+	"pointer-value": {
+		want:   &Order{Next: &Order{Tags: []string{"a"}}},
+		got:    &Order{Next: &Order{Tags: []string{"b"}}},
+		expect: []string{`.Next.Tags[0]: want "a", got "b"`},
+	},
+	"interface-type": {
+		want:   Order{Value: 1},
+		got:    Order{Value: "1"},
+		expect: []string{`.Value: want 1, got "1"`},
+	},
+	"time-value": {
+		want: Order{Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		got:  Order{Created: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		expect: []string{`.Created: want 2024-01-01 00:00:00 +0000 UTC, ` +
+			`got 2024-01-02 00:00:00 +0000 UTC`},
+	},
+	"ignore-path": {
+		config: []mock.DiffOption{mock.Ignore(".Orders[*].Items")},
+		want: Orders{Orders: []Order{{Items: map[string]Item{
+			"x": {Price: 10},
+		}}}},
+		got: Orders{Orders: []Order{{Items: map[string]Item{
+			"x": {Price: 12},
+		}}}},
+	},
+	"ignore-tag": {
+		config: []mock.DiffOption{mock.IgnoreTag("diff")},
+		want:   Order{ID: "a"},
+		got:    Order{ID: "b"},
+	},
+	"ignore-tag-missing": {
+		want:   Order{ID: "a"},
+		got:    Order{ID: "b"},
+		expect: []string{`.ID: want "a", got "b"`},
+	},
+}
+
+func TestPaths(t *testing.T) {
+	test.Map(t, pathsTestCases).
+		Run(func(t test.Test, param PathsParams) {
+			// Given
+			config := mock.NewDiffConfig(param.config...)
+
+			// When
+			diffs := config.Paths(param.want, param.got)
+
+			// Then
+			assert.Equal(t, param.expect, diffs)
+		})
+}
+
+func TestPathsCycle(t *testing.T) {
+	// Given
+	want, got := &Order{ID: "a"}, &Order{ID: "b"}
+	want.Next, got.Next = want, got
+	config := mock.NewDiffConfig()
+
+	// When
+	diffs := config.Paths(want, got)
+
+	// Then
+	assert.Equal(t, []string{`.ID: want "a", got "b"`}, diffs)
+}
+
+type PathsEqualParams struct {
+	config       []mock.Option
+	want         any
+	got          any
+	expect       bool
+	expectString string
+}
+
+var pathsEqualTestCases = map[string]PathsEqualParams{
+	"match": {
+		want:         Item{Price: 1},
+		got:          Item{Price: 1},
+		expect:       true,
+		expectString: `mock_test.Item(mock_test.Item{Price:1, note:""})`,
+	},
+	"mismatch": {
+		want:   Item{Price: 1},
+		got:    Item{Price: 2},
+		expect: false,
+		expectString: `mock_test.Item(mock_test.Item{Price:1, note:""})` +
+			"\nDiff (-want, +got):\n.Price: want 1, got 2\n",
+	},
+	"ignored-mismatch": {
+		config:       []mock.Option{mock.Ignore(".Price")},
+		want:         Item{Price: 1},
+		got:          Item{Price: 2},
+		expect:       true,
+		expectString: `mock_test.Item(mock_test.Item{Price:1, note:""})`,
+	},
+	"string-mismatch": {
+		want:   "a",
+		got:    "b",
+		expect: false,
+		expectString: `string("a")` + "\nDiff (-want, +got):\n" +
+			diff("1 +1", "-a\n+b\n"),
+	},
+}
+
+func TestPathsEqual(t *testing.T) {
+	test.Map(t, pathsEqualTestCases).
+		Run(func(t test.Test, param PathsEqualParams) {
+			// Given
+			mocks := mock.NewMocks(t).Config(mock.Mode(mock.DiffPaths)).
+				Config(param.config...)
+			matcher := mocks.Equal(param.want)
+
+			// When
+			result := matcher.Matches(param.got)
+
+			// Then
+			assert.Equal(t, param.expect, result)
+			assert.Equal(t, param.expectString, matcher.String())
+		})
+}
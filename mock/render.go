@@ -0,0 +1,248 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	// ansiRed is the ANSI escape sequence to start red text output.
+	ansiRed = "\x1b[31m"
+	// ansiGreen is the ANSI escape sequence to start green text output.
+	ansiGreen = "\x1b[32m"
+	// ansiReset is the ANSI escape sequence to reset the text output.
+	ansiReset = "\x1b[0m"
+)
+
+// Colors sets whether changes in a diff are highlighted using ANSI colors,
+// i.e. removed parts in red and added parts in green. Default is false.
+func Colors(enable bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.Colors(enable)
+	}
+}
+
+// NormalizeJSON sets whether strings are normalized before diffing, if both
+// values are JSON objects or arrays. The normalization sorts the object keys
+// and pretty prints the values to create a readable line-based diff. If the
+// normalized values are equal, the raw values are diffed. Default is false.
+func NormalizeJSON(enable bool) DiffOption {
+	return func(config *DiffConfig) {
+		config.NormalizeJSON(enable)
+	}
+}
+
+// Colors sets whether changes in a diff are highlighted using ANSI colors,
+// i.e. removed parts in red and added parts in green. Default is false.
+func (c *DiffConfig) Colors(enable bool) {
+	c.colors = enable
+}
+
+// NormalizeJSON sets whether strings are normalized before diffing, if both
+// values are JSON objects or arrays. The normalization sorts the object keys
+// and pretty prints the values to create a readable line-based diff. If the
+// normalized values are equal, the raw values are diffed. Default is false.
+func (c *DiffConfig) NormalizeJSON(enable bool) {
+	c.json = enable
+}
+
+// regexToken is a regular expression to split a string into words, white
+// space sequences, and single other characters for the inline diff.
+var regexToken = regexp.MustCompile(`[\p{L}\p{N}_]+|\s+|.`)
+
+// inline returns an inline word- and character-level diff of the given
+// strings marking removed parts as `[-removed-]` and added parts as
+// `{+added+}`, or using colors if enabled.
+func (c *DiffConfig) inline(want, got string) string {
+	if want == got {
+		return ""
+	}
+
+	result := c.mark(regexToken.FindAllString(want, -1),
+		regexToken.FindAllString(got, -1), true)
+	if !strings.HasSuffix(result, "\n") {
+		return result + "\n"
+	}
+	return result
+}
+
+// mark returns the inline diff of the given expected and actual tokens. If
+// refine is set, a single replaced word is diffed on character level, if
+// both words are similar enough.
+func (c *DiffConfig) mark(wtokens, gtokens []string, refine bool) string {
+	var builder strings.Builder
+	matcher := difflib.NewMatcher(wtokens, gtokens)
+	for _, op := range matcher.GetOpCodes() {
+		removed := strings.Join(wtokens[op.I1:op.I2], "")
+		added := strings.Join(gtokens[op.J1:op.J2], "")
+		switch op.Tag {
+		case 'e':
+			builder.WriteString(removed)
+		case 'd':
+			builder.WriteString(c.removed(removed))
+		case 'i':
+			builder.WriteString(c.added(added))
+		case 'r':
+			if refine && op.I2-op.I1 == 1 && op.J2-op.J1 == 1 {
+				builder.WriteString(c.chars(removed, added))
+			} else {
+				builder.WriteString(c.removed(removed) + c.added(added))
+			}
+		}
+	}
+	return builder.String()
+}
+
+// chars returns the character-level inline diff of the given replaced and
+// replacing word, if both words are similar enough. Otherwise the words are
+// marked as removed and added as a whole.
+func (c *DiffConfig) chars(want, got string) string {
+	wchars, gchars := strings.Split(want, ""), strings.Split(got, "")
+	if difflib.NewMatcher(wchars, gchars).Ratio() < 0.5 {
+		return c.removed(want) + c.added(got)
+	}
+	return c.mark(wchars, gchars, false)
+}
+
+// sideBySide returns a line-based side-by-side diff of the given strings
+// showing the expected lines on the left and the actual lines on the right.
+// The marker between the columns signals changed (`|`), removed (`<`), and
+// added (`>`) lines.
+func (c *DiffConfig) sideBySide(want, got string) string {
+	if want == got {
+		return ""
+	}
+
+	wlines := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	glines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	width := utf8.RuneCountInString(c.dlib.FromFile)
+	for _, line := range wlines {
+		width = max(width, utf8.RuneCountInString(line))
+	}
+
+	var builder strings.Builder
+	c.column(&builder, width, c.dlib.FromFile, ' ', c.dlib.ToFile)
+	matcher := difflib.NewMatcher(wlines, glines)
+	for _, op := range matcher.GetOpCodes() {
+		for index := 0; index < max(op.I2-op.I1, op.J2-op.J1); index++ {
+			wline, gline, marker := "", "", '|'
+			if op.I1+index < op.I2 {
+				wline = wlines[op.I1+index]
+			} else {
+				marker = '>'
+			}
+			if op.J1+index < op.J2 {
+				gline = glines[op.J1+index]
+			} else {
+				marker = '<'
+			}
+			if op.Tag == 'e' {
+				marker = ' '
+			}
+			c.column(&builder, width, wline, marker, gline)
+		}
+	}
+	return builder.String()
+}
+
+// column writes a single line of the side-by-side diff with the given
+// expected and actual line separated by the given marker.
+func (c *DiffConfig) column(
+	builder *strings.Builder, width int, want string, marker rune, got string,
+) {
+	padding := strings.Repeat(" ", width-utf8.RuneCountInString(want))
+	if c.colors && (marker == '|' || marker == '<') && want != "" {
+		want = ansiRed + want + ansiReset
+	}
+	if c.colors && (marker == '|' || marker == '>') && got != "" {
+		got = ansiGreen + got + ansiReset
+	}
+	builder.WriteString(strings.TrimRight(
+		fmt.Sprintf("%s%s %c %s", want, padding, marker, got), " ") + "\n")
+}
+
+// colorize highlights the removed and added lines of the given unified diff
+// using ANSI colors if enabled.
+func (c *DiffConfig) colorize(diff string) string {
+	if !c.colors || diff == "" {
+		return diff
+	}
+
+	lines := strings.SplitAfter(diff, "\n")
+	for index, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+		case strings.HasPrefix(line, "-"):
+			lines[index] = ansiRed + text + ansiReset + line[len(text):]
+		case strings.HasPrefix(line, "+"):
+			lines[index] = ansiGreen + text + ansiReset + line[len(text):]
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// removed returns the marked removed part of an inline diff.
+func (c *DiffConfig) removed(text string) string {
+	if c.colors {
+		return ansiRed + text + ansiReset
+	}
+	return "[-" + text + "-]"
+}
+
+// added returns the marked added part of an inline diff.
+func (c *DiffConfig) added(text string) string {
+	if c.colors {
+		return ansiGreen + text + ansiReset
+	}
+	return "{+" + text + "+}"
+}
+
+// normalizeJSON returns the normalized, i.e. key sorted and pretty printed,
+// representation of the given strings, if both strings are JSON objects or
+// arrays. Otherwise, the strings are returned unchanged. This includes the
+// case where the normalized strings are equal, so that the diff still shows
+// the raw differences that made the strings unequal in the first place.
+func normalizeJSON(want, got string) (string, string) {
+	wnorm, wok := prettyJSON(want)
+	gnorm, gok := prettyJSON(got)
+	if wok && gok && wnorm != gnorm {
+		return wnorm, gnorm
+	}
+	return want, got
+}
+
+// prettyJSON returns the key sorted and pretty printed representation of the
+// given string and whether the string is a valid JSON object or array.
+func prettyJSON(str string) (string, bool) {
+	trimmed := strings.TrimSpace(str)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+
+	var value any
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	} else if _, err := decoder.Token(); err != io.EOF {
+		return "", false
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return "", false
+	}
+	return buffer.String(), true
+}
@@ -0,0 +1,147 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+type RenderParams struct {
+	config []mock.DiffOption
+	want   any
+	got    any
+	expect string
+}
+
+var renderTestCases = map[string]RenderParams{
+	"inline-equal": {
+		config: []mock.DiffOption{mock.Mode(mock.DiffInline)},
+		want:   "select * from table",
+		got:    "select * from table",
+		expect: "",
+	},
+	"inline-words": {
+		config: []mock.DiffOption{mock.Mode(mock.DiffInline)},
+		want:   "select id from orders where price > 10",
+		got:    "select name from orders where price >= 10",
+		expect: "select [-id-]{+name+} from orders where price >{+=+} 10\n",
+	},
+	"inline-chars": {
+		config: []mock.DiffOption{mock.Mode(mock.DiffInline)},
+		want:   `{"name":"hello world","value":12}`,
+		got:    `{"name":"hello word","value":13}`,
+		expect: `{"name":"hello wor[-l-]d","value":1[-2-]{+3+}}` + "\n",
+	},
+	"inline-colors": {
+		config: []mock.DiffOption{
+			mock.Mode(mock.DiffInline), mock.Colors(true),
+		},
+		want:   "a b c",
+		got:    "a x c",
+		expect: "a \x1b[31mb\x1b[0m\x1b[32mx\x1b[0m c\n",
+	},
+	"inline-struct": {
+		config: []mock.DiffOption{mock.Mode(mock.DiffInline)},
+		want:   Item{Price: 10},
+		got:    Item{Price: 12},
+		expect: "(mock_test.Item) {\n" +
+			"  Price: (int) 1[-0-]{+2+},\n" +
+			"  note: (string) \"\"\n" +
+			"}\n",
+	},
+	"side-by-side": {
+		config: []mock.DiffOption{mock.Mode(mock.DiffSideBySide)},
+		want:   "alpha\nbeta\ngamma\ndelta\n",
+		got:    "alpha\nBETA\ngamma\nepsilon\nzeta\n",
+		expect: "" +
+			"Want    Got\n" +
+			"alpha   alpha\n" +
+			"beta  | BETA\n" +
+			"gamma   gamma\n" +
+			"delta | epsilon\n" +
+			"      > zeta\n",
+	},
+	"side-by-side-removed": {
+		config: []mock.DiffOption{mock.Mode(mock.DiffSideBySide)},
+		want:   "alpha\nbeta\n",
+		got:    "alpha\n",
+		expect: "" +
+			"Want    Got\n" +
+			"alpha   alpha\n" +
+			"beta  <\n",
+	},
+	"side-by-side-colors": {
+		config: []mock.DiffOption{
+			mock.Mode(mock.DiffSideBySide), mock.Colors(true),
+		},
+		want: "a\nb\n",
+		got:  "a\nc\n",
+		expect: "" +
+			"Want   Got\n" +
+			"a      a\n" +
+			"\x1b[31mb\x1b[0m    | \x1b[32mc\x1b[0m\n",
+	},
+	"unified-colors": {
+		config: []mock.DiffOption{mock.Colors(true)},
+		want:   "a\nb\n",
+		got:    "a\nc\n",
+		expect: diff("1,3 +1,3", " a\n"+
+			"\x1b[31m-b\x1b[0m\n"+
+			"\x1b[32m+c\x1b[0m\n"+
+			" \n"),
+	},
+	"json-normalized": {
+		config: []mock.DiffOption{mock.NormalizeJSON(true)},
+		want:   `{"b":1,"a":[1,2]}`,
+		got:    `{"a":[1,3],"b":1}`,
+		expect: diff("1,7 +1,7", " {\n"+
+			"   \"a\": [\n"+
+			"     1,\n"+
+			"-    2\n"+
+			"+    3\n"+
+			"   ],\n"+
+			"   \"b\": 1\n"+
+			" }\n"),
+	},
+	"json-normalized-equal": {
+		config: []mock.DiffOption{mock.NormalizeJSON(true)},
+		want:   `{"b":1, "a":"<x>"}`,
+		got:    `{"a":"<x>","b":1}`,
+		expect: diff("1 +1", "-{\"b\":1, \"a\":\"<x>\"}\n"+
+			"+{\"a\":\"<x>\",\"b\":1}\n"),
+	},
+	"json-normalized-invalid": {
+		config: []mock.DiffOption{mock.NormalizeJSON(true)},
+		want:   `{"a":1}`,
+		got:    `{"a":1} trailing`,
+		expect: diff("1 +1", "-{\"a\":1}\n+{\"a\":1} trailing\n"),
+	},
+	"json-normalized-inline": {
+		config: []mock.DiffOption{
+			mock.NormalizeJSON(true), mock.Mode(mock.DiffInline),
+		},
+		want: `{"b":"x","a":1}`,
+		got:  `{"a":2,"b":"x"}`,
+		expect: "{\n" +
+			"  \"a\": [-1-]{+2+},\n" +
+			"  \"b\": \"x\"\n" +
+			"}\n",
+	},
+}
+
+func TestRender(t *testing.T) {
+	test.Map(t, renderTestCases).
+		Run(func(t test.Test, param RenderParams) {
+			// Given
+			config := mock.NewDiffConfig(param.config...)
+
+			// When
+			result := config.Diff(param.want, param.got)
+
+			// Then
+			assert.Equal(t, param.expect, result)
+		})
+}
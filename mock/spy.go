@@ -0,0 +1,126 @@
+package mock
+
+import (
+	gosync "sync"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/tkrop/go-testing/internal/reflect"
+	"github.com/tkrop/go-testing/internal/sync"
+)
+
+// spy is the call recorder of the mock handler in spy mode.
+type spy struct {
+	// Mutex to synchronize concurrent calls.
+	mutex gosync.Mutex
+	// Recorded mock calls in order of execution.
+	calls []spyCall
+}
+
+// spyCall is a mock call recorded in spy mode.
+type spyCall struct {
+	// Constructor of the called mock.
+	creator reflect.Value
+	// Name of the called method.
+	method string
+	// Input arguments of the call.
+	args []reflect.Value
+}
+
+// Spy sets up the spy mode of the mock handler. In spy mode, all mocks created
+// by the mock handler accept any call, answer it with the default results set
+// up via [Mocks.Default] or with zero values, and record it for verification
+// via [Mocks.Verify]. Regular expected calls take precedence, i.e. calls
+// matching an expected call set up via [Mocks.Expect] or via `EXPECT()` are
+// answered as expected and not recorded. The spy mode must be set up on
+// creation of the mock handler, since it only applies to mocks created
+// afterwards.
+func Spy(enable bool) ConfigFunc {
+	return func(mocks *Mocks) {
+		if !enable {
+			mocks.spy = nil
+		} else if mocks.spy == nil {
+			mocks.spy = &spy{}
+		}
+	}
+}
+
+// Verify verifies the mock calls recorded in spy mode against the given mock
+// setup using the same ordering patterns as for expecting mock calls, i.e.
+// [Setup], [Chain], [Parallel], [Detach], and [Sub]. The recorded calls are
+// replayed in order of execution against a new set of mocks set up using the
+// given mock setup, so that deviations are reported as usual including the
+// diff of the arguments. Finally, the setup is checked for missing calls.
+func (mocks *Mocks) Verify(fncalls SetupFunc) *Mocks {
+	mocks.Ctrl.T.Helper()
+
+	t, format := mocks.Ctrl.T, NoGraph
+	if ut, ok := unwrapGraph(t); ok {
+		t, format = ut, mocks.graph.format
+	}
+
+	verify := (&Mocks{
+		Ctrl:  gomock.NewController(t),
+		wg:    sync.NewLenientWaitGroup(),
+		mocks: map[reflect.Type]any{},
+		args:  mocks.args,
+		diff:  mocks.diff,
+
+		defaults:  mocks.defaults,
+		decisions: newDecisions(),
+		graph:     newGraph(),
+	}).Config(GraphOnFailure(format))
+	verify.Expect(fncalls)
+
+	for _, call := range mocks.spyCalls() {
+		method := reflect.ValueOf(verify.Get(call.creator)).
+			MethodByName(call.method)
+		if method.Type().IsVariadic() {
+			method.CallSlice(call.args)
+		} else {
+			method.Call(call.args)
+		}
+	}
+
+	verify.Ctrl.Finish()
+	return mocks
+}
+
+// spyCalls returns a copy of the mock calls recorded in spy mode.
+func (mocks *Mocks) spyCalls() []spyCall {
+	if mocks.spy == nil {
+		return nil
+	}
+
+	mocks.spy.mutex.Lock()
+	defer mocks.spy.mutex.Unlock()
+	return append([]spyCall{}, mocks.spy.calls...)
+}
+
+// spyOn sets up the given mock created by the given constructor to accept any
+// call of its methods, that is not matching a regular expected call, to answer
+// it with the default results, and to record it in spy mode.
+func (mocks *Mocks) spyOn(creator reflect.Value, mock any) {
+	spy, value := mocks.spy, reflect.ValueOf(mock)
+	for index := range value.NumMethod() {
+		name := value.Type().Method(index).Name
+		if name == "EXPECT" {
+			continue
+		}
+
+		mtype := value.Method(index).Type()
+		mocks.fallbacks.add(mocks.Ctrl, mock, name,
+			func(in []reflect.Value) []reflect.Value {
+				spy.record(spyCall{creator: creator, method: name, args: in})
+				return mocks.defaults.answer(value.Type(), name, mtype)
+			})
+	}
+}
+
+// record records the given mock call.
+func (s *spy) record(call spyCall) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.calls = append(s.calls, call)
+}
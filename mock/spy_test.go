@@ -0,0 +1,146 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+type SpyParams struct {
+	defaults func(*mock.Mocks)
+	call     func(test.Test, *mock.Mocks)
+	verify   mock.SetupFunc
+	expect   test.Expect
+}
+
+var spyTestCases = map[string]SpyParams{
+	"no-calls": {
+		call:   func(test.Test, *mock.Mocks) {},
+		expect: test.Success,
+	},
+	"zero-results": {
+		call: func(t test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+			assert.Empty(t, mock.Get(mocks, NewMockIFace[string]).CallB("b"))
+		},
+		verify: mock.Chain(CallA("a"), CallB("b", "")),
+		expect: test.Success,
+	},
+	"default-results": {
+		defaults: func(mocks *mock.Mocks) {
+			mocks.Default(IFace[string].CallB, "c")
+		},
+		call: func(t test.Test, mocks *mock.Mocks) {
+			assert.Equal(t, "c",
+				mock.Get(mocks, NewMockIFace[string]).CallB("a"))
+			assert.Equal(t, "c",
+				mock.Get(mocks, NewMockIFace[string]).CallB("b"))
+		},
+		verify: mock.Chain(CallB("a", ""), CallB("b", "")),
+		expect: test.Success,
+	},
+	"default-results-other-type": {
+		defaults: func(mocks *mock.Mocks) {
+			mocks.Default(IFace[int].CallB, 1)
+		},
+		call: func(t test.Test, mocks *mock.Mocks) {
+			assert.Empty(t, mock.Get(mocks, NewMockIFace[string]).CallB("a"))
+		},
+		verify: CallB("a", ""),
+		expect: test.Success,
+	},
+	"parallel-calls": {
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockXFace).CallC("c")
+			mock.Get(mocks, NewMockIFace[string]).CallA("b")
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+		},
+		verify: mock.Parallel(
+			mock.Chain(CallA("b"), CallA("a")),
+			CallC("c"),
+		),
+		expect: test.Success,
+	},
+	"detached-calls": {
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("b")
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+		},
+		verify: mock.Chain(
+			mock.Detach(mock.Both, CallA("a")),
+			CallA("b"),
+		),
+		expect: test.Success,
+	},
+	"wrong-order": {
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("b")
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+		},
+		verify: mock.Chain(CallA("a"), CallA("b")),
+		expect: test.Failure,
+	},
+	"wrong-argument": {
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("b")
+		},
+		verify: CallA("a"),
+		expect: test.Failure,
+	},
+	"missing-call": {
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+		},
+		verify: mock.Chain(CallA("a"), CallA("b")),
+		expect: test.Failure,
+	},
+	"unexpected-call": {
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockXFace).CallC("c")
+		},
+		expect: test.Failure,
+	},
+	"expect-after-spy": {
+		call: func(t test.Test, mocks *mock.Mocks) {
+			iface := mock.Get(mocks, NewMockIFace[string])
+			iface.EXPECT().CallB("a").Return("x")
+
+			assert.Equal(t, "", iface.CallB("b"))
+			assert.Equal(t, "x", iface.CallB("a"))
+			assert.Equal(t, "", iface.CallB("a"))
+		},
+		verify: mock.Chain(CallB("b", ""), CallB("a", "")),
+		expect: test.Success,
+	},
+	"setup-after-spy": {
+		call: func(t test.Test, mocks *mock.Mocks) {
+			iface := mock.Get(mocks, NewMockIFace[string])
+			mocks.Expect(CallB("a", "x"))
+
+			assert.Equal(t, "x", iface.CallB("a"))
+			assert.Equal(t, "", iface.CallB("b"))
+		},
+		verify: CallB("b", ""),
+		expect: test.Success,
+	},
+}
+
+func TestSpy(t *testing.T) {
+	test.Map(t, spyTestCases).
+		Run(func(t test.Test, param SpyParams) {
+			// Given
+			mocks := mock.NewMocks(t, mock.Spy(true))
+			if param.defaults != nil {
+				param.defaults(mocks)
+			}
+
+			// When
+			param.call(t, mocks)
+
+			// Then
+			mocks.Verify(param.verify)
+		})
+}
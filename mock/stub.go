@@ -0,0 +1,92 @@
+package mock
+
+import "github.com/tkrop/go-testing/internal/reflect"
+
+// stubs is the configuration of the mocks and methods in stub mode.
+type stubs struct {
+	// Constructor types of the mocks stubbed completely.
+	mocks map[reflect.Type]bool
+	// Interface types of the stubbed methods by method name.
+	methods map[string][]reflect.Type
+}
+
+// Stub sets up the stub mode for the given targets, that are either mock
+// constructors, e.g. `NewMockLogger`, to stub all methods of a mock, or
+// interface methods, e.g. `Logger.Info`, to stub single methods. Stubbed
+// methods answer unexpected calls with the default results set up via
+// [Mocks.Default] or with zero values instead of failing the test, while
+// strict expectations stay in place for all other methods.
+//
+// Expectations set up via [Mocks.Expect] or via `EXPECT()` take precedence
+// over stubs, even if set up after creating the mock, i.e. only calls that are
+// not matching any expectation are answered by the stub. The
+// stub mode must be set up on creation of the mock handler, since it only
+// applies to mocks created afterwards. The function panics, if a target is
+// neither a mock constructor nor an interface method.
+func Stub(targets ...any) ConfigFunc {
+	return func(mocks *Mocks) {
+		if mocks.stubs == nil {
+			mocks.stubs = &stubs{
+				mocks:   map[reflect.Type]bool{},
+				methods: map[string][]reflect.Type{},
+			}
+		}
+
+		for _, target := range targets {
+			mocks.stubs.add(target)
+		}
+	}
+}
+
+// add adds the given mock constructor or interface method to the stubs.
+func (s *stubs) add(target any) {
+	ftype := reflect.TypeOf(target)
+	if ftype == nil || ftype.Kind() != reflect.Func {
+		panic(NewErrStubNotSupported(target))
+	}
+
+	ctype := reflect.TypeOf((*Controller)(nil))
+	if ftype.NumIn() == 1 && ftype.In(0) == ctype && ftype.NumOut() == 1 {
+		s.mocks[ftype] = true
+	} else if ftype.NumIn() > 0 && ftype.In(0).Kind() == reflect.Interface {
+		name := methodName(target)
+		s.methods[name] = append(s.methods[name], ftype.In(0))
+	} else {
+		panic(NewErrStubNotSupported(target))
+	}
+}
+
+// stubbed returns whether the given method of the given mock created by the
+// given constructor is stubbed.
+func (s *stubs) stubbed(
+	creator reflect.Type, mtype reflect.Type, method string,
+) bool {
+	if s.mocks[creator] {
+		return true
+	}
+	for _, iface := range s.methods[method] {
+		if mtype.Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// stubOn sets up the stubbed methods of the given mock created by the given
+// constructor to answer any unexpected call with the default results.
+func (mocks *Mocks) stubOn(creator reflect.Value, mock any) {
+	stubs, value := mocks.stubs, reflect.ValueOf(mock)
+	for index := range value.NumMethod() {
+		name := value.Type().Method(index).Name
+		if name == "EXPECT" ||
+			!stubs.stubbed(creator.Type(), value.Type(), name) {
+			continue
+		}
+
+		mtype := value.Method(index).Type()
+		mocks.fallbacks.add(mocks.Ctrl, mock, name,
+			func([]reflect.Value) []reflect.Value {
+				return mocks.defaults.answer(value.Type(), name, mtype)
+			})
+	}
+}
@@ -0,0 +1,162 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+type StubParams struct {
+	stubs    []any
+	defaults func(*mock.Mocks)
+	setup    mock.SetupFunc
+	call     func(test.Test, *mock.Mocks)
+	expect   test.Expect
+}
+
+var stubTestCases = map[string]StubParams{
+	"stub-mock-zero-results": {
+		stubs: []any{NewMockIFace[string]},
+		call: func(t test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+			assert.Empty(t, mock.Get(mocks, NewMockIFace[string]).CallB("b"))
+		},
+		expect: test.Success,
+	},
+	"stub-mock-default-results": {
+		stubs: []any{NewMockIFace[string]},
+		defaults: func(mocks *mock.Mocks) {
+			mocks.Default(IFace[string].CallB, "c")
+		},
+		call: func(t test.Test, mocks *mock.Mocks) {
+			assert.Equal(t, "c",
+				mock.Get(mocks, NewMockIFace[string]).CallB("a"))
+		},
+		expect: test.Success,
+	},
+	"stub-method-default-results": {
+		stubs: []any{IFace[string].CallB},
+		defaults: func(mocks *mock.Mocks) {
+			mocks.Default(IFace[string].CallB, "c")
+		},
+		call: func(t test.Test, mocks *mock.Mocks) {
+			assert.Equal(t, "c",
+				mock.Get(mocks, NewMockIFace[string]).CallB("a"))
+		},
+		expect: test.Success,
+	},
+	"stub-method-other-type": {
+		stubs: []any{IFace[int].CallB},
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallB("a")
+		},
+		expect: test.Failure,
+	},
+	"stub-method-strict-other-method": {
+		stubs: []any{IFace[string].CallB},
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("a")
+		},
+		expect: test.Failure,
+	},
+	"stub-mock-strict-other-mock": {
+		stubs: []any{NewMockIFace[string]},
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockXFace).CallC("c")
+		},
+		expect: test.Failure,
+	},
+	"expect-precedence": {
+		stubs: []any{NewMockIFace[string]},
+		setup: CallB("a", "b"),
+		call: func(t test.Test, mocks *mock.Mocks) {
+			assert.Empty(t, mock.Get(mocks, NewMockIFace[string]).CallB("c"))
+			assert.Equal(t, "b",
+				mock.Get(mocks, NewMockIFace[string]).CallB("a"))
+			assert.Empty(t, mock.Get(mocks, NewMockIFace[string]).CallB("a"))
+		},
+		expect: test.Success,
+	},
+	"expect-missing-call": {
+		stubs: []any{NewMockIFace[string]},
+		setup: CallA("a"),
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			mock.Get(mocks, NewMockIFace[string]).CallA("b")
+		},
+		expect: test.Failure,
+	},
+	"expect-after-stub": {
+		stubs: []any{NewMockIFace[string]},
+		call: func(t test.Test, mocks *mock.Mocks) {
+			iface := mock.Get(mocks, NewMockIFace[string])
+			iface.EXPECT().CallB("a").Return("b")
+
+			assert.Empty(t, iface.CallB("c"))
+			assert.Equal(t, "b", iface.CallB("a"))
+			assert.Empty(t, iface.CallB("a"))
+		},
+		expect: test.Success,
+	},
+	"expect-after-stub-missing-call": {
+		stubs: []any{NewMockIFace[string]},
+		call: func(_ test.Test, mocks *mock.Mocks) {
+			iface := mock.Get(mocks, NewMockIFace[string])
+			iface.EXPECT().CallB("a").Return("b")
+
+			iface.CallB("c")
+		},
+		expect: test.Failure,
+	},
+}
+
+func TestStub(t *testing.T) {
+	test.Map(t, stubTestCases).
+		Run(func(t test.Test, param StubParams) {
+			// Given
+			mocks := mock.NewMocks(t, mock.Stub(param.stubs...))
+			if param.defaults != nil {
+				param.defaults(mocks)
+			}
+			mocks.Expect(param.setup)
+
+			// When
+			param.call(t, mocks)
+		})
+}
+
+type StubPanicParams struct {
+	target      any
+	expectError error
+}
+
+var stubPanicTestCases = map[string]StubPanicParams{
+	"nil": {
+		expectError: mock.NewErrStubNotSupported(nil),
+	},
+	"no-func": {
+		target:      "target",
+		expectError: mock.NewErrStubNotSupported("target"),
+	},
+	"no-method": {
+		target:      func(string) {},
+		expectError: mock.NewErrStubNotSupported(func(string) {}),
+	},
+}
+
+func TestStubPanic(t *testing.T) {
+	test.Map(t, stubPanicTestCases).
+		Run(func(t test.Test, param StubPanicParams) {
+			// Given
+			defer test.Recover(t, param.expectError)
+
+			// When
+			mock.NewMocks(t, mock.Stub(param.target))
+
+			// Then
+			require.Fail(t, "not paniced")
+		})
+}
@@ -182,9 +182,10 @@ func (b *builder[T]) Get(name string) any {
 // declaration is returned as fallback. If no matching field is found, the
 // default value is returned.
 func (b *builder[T]) Find(deflt any, names ...string) any {
+	dtype := typeOf(deflt)
 	for _, name := range names {
 		if field := b.targetValueOf().FieldByName(name); field.IsValid() {
-			if b.canBeAssigned(reflect.TypeOf(deflt), field.Type()) {
+			if b.canBeAssigned(dtype, field.Type()) {
 				return b.valuePtr(field).Elem().Interface()
 			}
 		}
@@ -194,7 +195,7 @@ func (b *builder[T]) Find(deflt any, names ...string) any {
 		// Fallback to the first field with a matching type.
 		for i := range b.rtype.NumField() {
 			tfield := b.rtype.Field(i)
-			if b.canBeAssigned(reflect.TypeOf(deflt), tfield.Type) {
+			if b.canBeAssigned(dtype, tfield.Type) {
 				vfield := b.targetValueOf().Field(i)
 				return b.valuePtr(vfield).Elem().Interface()
 			}
@@ -268,6 +269,15 @@ func (*builder[T]) valueOf(field reflect.Value, value any) reflect.Value {
 	return reflect.ValueOf(value)
 }
 
+// typeOf returns the reflection type of the given value. If the value is nil,
+// the type of the empty interface is returned, that any type is assignable to.
+func typeOf(value any) reflect.Type {
+	if value == nil {
+		return reflect.TypeFor[any]()
+	}
+	return reflect.TypeOf(value)
+}
+
 // Find returns the first value of a struct field from the given list of field
 // names with a type matching the default value type. If the name list is empty
 // or contains a star (`*`), the first matching field in order of the struct
@@ -276,16 +286,19 @@ func (*builder[T]) valueOf(field reflect.Value, value any) reflect.Value {
 //
 // The `param` object can be a struct, a pointer to a struct, or an arbitrary
 // value matching the default value type. In the last case, the arbitrary value
-// is returned as is.
+// is returned as is. A nil default value, e.g. of type `any`, is matching any
+// field type.
 func Find[P, T any](param P, deflt T, names ...string) T {
-	pt, dt := reflect.TypeOf(param), reflect.TypeOf(deflt)
+	pt, dt := reflect.TypeOf(param), typeOf(deflt)
 	switch {
+	case pt == nil:
+		return deflt
 	case pt.Kind() == dt.Kind():
 		return reflect.ValueOf(param).Interface().(T)
-	case pt.Kind() == reflect.Struct:
-		return NewAccessor[P](param).Find(deflt, names...).(T)
-	case pt.Kind() == reflect.Ptr && pt.Elem().Kind() == reflect.Struct:
-		return NewAccessor[P](param).Find(deflt, names...).(T)
+	case pt.Kind() == reflect.Struct,
+		pt.Kind() == reflect.Ptr && pt.Elem().Kind() == reflect.Struct:
+		value, _ := NewAccessor[P](param).Find(deflt, names...).(T)
+		return value
 	default:
 		return deflt
 	}
@@ -1022,6 +1022,24 @@ var findTestCases = map[string]FindParams{
 		expect: "init",
 	},
 
+	"struct-nil-match": {
+		param:  structInit,
+		deflt:  nil,
+		names:  []string{"s"},
+		expect: "init",
+	},
+	"struct-nil-invalid": {
+		param:  structInit,
+		deflt:  nil,
+		names:  []string{"invalid"},
+		expect: nil,
+	},
+	"nil-param": {
+		param:  nil,
+		deflt:  "default",
+		expect: "default",
+	},
+
 	"ptr-match": {
 		param:  structPtrInit,
 		deflt:  "default",
@@ -0,0 +1,195 @@
+package test
+
+import (
+	"errors"
+	"strings"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/tkrop/go-testing/internal/reflect"
+	"github.com/tkrop/go-testing/mock"
+)
+
+// CheckFunc defines the common parameterized test function signature that
+// returns the result and the error of the tested call for automatic checking.
+type CheckFunc[P any] func(t Test, param P) (any, error)
+
+// checkReserved is the set of reserved expectation field names that are not
+// used for checking the result value.
+var checkReserved = map[string]bool{
+	"expectError": true, "ExpectError": true,
+	"expectPanic": true, "ExpectPanic": true,
+}
+
+// checkErrors is the set of expectation field names that are used for
+// checking the error.
+var checkErrors = map[string]bool{
+	"expectError": true, "ExpectError": true,
+}
+
+// checkResult is the set of expectation field names that are used for
+// checking the complete result value.
+var checkResult = map[string]bool{
+	"expect": true, "Expect": true,
+	"expectResult": true, "ExpectResult": true,
+}
+
+// checkExpect is an expectation field of a parameter set.
+type checkExpect struct {
+	// Name of the expectation field.
+	name string
+	// Value of the expectation field.
+	value any
+}
+
+// check checks the given result and error against the expectations provided
+// by the given parameter set. The error is checked against the `expectError`
+// field. The result is either checked against a complete result field, i.e.
+// `expect` or `expectResult`, or else each `expect`-prefixed field is checked
+// in order of declaration against the result field with the same name. Fields
+// of type [Expect] and function types are ignored.
+func check[P any](t Test, param P, result any, err error) {
+	t.Helper()
+
+	value := reflect.ValueOf(param)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		checkError(t, nil, err)
+		return
+	}
+
+	fields := []checkExpect{}
+	for index := range value.NumField() {
+		field := value.Type().Field(index)
+		if !strings.HasPrefix(field.Name, "expect") &&
+			!strings.HasPrefix(field.Name, "Expect") {
+			continue
+		} else if field.Type == reflect.TypeOf(Success) ||
+			field.Type.Kind() == reflect.Func {
+			continue
+		}
+		fields = append(fields, checkExpect{
+			name: field.Name, value: reflect.FieldOf(value, index),
+		})
+	}
+
+	if expect := checkField(fields, checkErrors); expect != nil {
+		checkError(t, expect.value, err)
+	} else {
+		checkError(t, nil, err)
+	}
+
+	if expect := checkField(fields, checkResult); expect != nil {
+		checkValue(t, "result", expect.value, result)
+		return
+	}
+
+	for _, expect := range fields {
+		if !checkReserved[expect.name] {
+			name := expect.name[len("expect"):]
+			checkValue(t, name, expect.value,
+				checkResultField(t, name, result))
+		}
+	}
+}
+
+// checkField returns the first expectation field matching the given set of
+// field names.
+func checkField(fields []checkExpect, names map[string]bool) *checkExpect {
+	for index, field := range fields {
+		if names[field.name] {
+			return &fields[index]
+		}
+	}
+	return nil
+}
+
+// checkResultField returns the value of the result field matching the given
+// name case insensitive. A nil result or nil pointer result is handled as zero
+// value struct. If the result is not a struct or the field does not exist, a
+// failure is reported.
+func checkResultField(t Test, name string, result any) any {
+	t.Helper()
+
+	if result == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(result)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value = reflect.Zero(value.Type().Elem())
+		} else {
+			value = value.Elem()
+		}
+	}
+
+	if value.Kind() == reflect.Struct {
+		for index := range value.NumField() {
+			if strings.EqualFold(value.Type().Field(index).Name, name) {
+				return reflect.FieldOf(value, index)
+			}
+		}
+	}
+
+	t.Errorf("result field not found [%s]: %T", name, result)
+	return nil
+}
+
+// checkError checks the given error against the given error expectation. If
+// the expectation is nil, no error is expected. Else the error is matched via
+// [errors.Is], via [errors.As] and equality, via an expected error message,
+// or via a [gomock.Matcher].
+func checkError(t Test, expect any, err error) {
+	t.Helper()
+
+	if expect == nil {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	} else if !matchError(expect, err) {
+		t.Errorf("error mismatch: want %s, got %v", panicString(expect), err)
+	}
+}
+
+// matchError matches the given error against the given error expectation.
+func matchError(expect any, err error) bool {
+	if err == nil {
+		return false
+	} else if target, ok := expect.(error); ok {
+		ptr := reflect.New(reflect.TypeOf(target))
+		if errors.As(err, ptr.Interface()) &&
+			gomock.Eq(target).Matches(ptr.Elem().Interface()) {
+			return true
+		}
+	}
+	return matchPanic(expect, err)
+}
+
+// checkValue checks the given actual value against the given expected value
+// reporting a failure with a diff on mismatch. A nil actual value is checked
+// as zero value of the expected value type.
+func checkValue(t Test, name string, want, got any) {
+	t.Helper()
+
+	if matcher, ok := want.(gomock.Matcher); ok {
+		if !matcher.Matches(got) {
+			t.Errorf("%s mismatch: want %s, got %#v", name, matcher, got)
+		}
+		return
+	}
+
+	if got == nil && want != nil {
+		got = reflect.Zero(reflect.TypeOf(want)).Interface()
+	}
+
+	if !gomock.Eq(want).Matches(got) {
+		if diff := mock.NewDiffConfig().Diff(want, got); diff != "" {
+			t.Errorf("%s mismatch:\nDiff (-want, +got):\n%s", name, diff)
+		} else {
+			t.Errorf("%s mismatch: want %#v, got %#v", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package test_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+// CheckResult is a result structure for testing field-wise checks.
+type CheckResult struct {
+	Name  string
+	Value int
+	items []string
+}
+
+// errOpen is a path error used for testing error matching via `errors.As`.
+var errOpen = &fs.PathError{Op: "open", Err: fs.ErrNotExist}
+
+type CheckValueParams struct {
+	setup       mock.SetupFunc
+	result      any
+	err         error
+	expect      any
+	expectError any
+}
+
+var checkValueTestCases = map[string]CheckValueParams{
+	"nil-result": {},
+	"value-result": {
+		result: "value",
+		expect: "value",
+	},
+	"struct-result": {
+		result: CheckResult{Name: "name", Value: 1},
+		expect: CheckResult{Name: "name", Value: 1},
+	},
+	"nil-zero-result": {
+		expect: 0,
+	},
+	"matcher-result": {
+		result: "value",
+		expect: gomock.Any(),
+	},
+
+	"error-is": {
+		err:         fmt.Errorf("wrapped: %w", assert.AnError),
+		expectError: assert.AnError,
+	},
+	"error-as": {
+		err:         fmt.Errorf("wrapped: %w", errOpen),
+		expectError: &fs.PathError{Op: "open", Err: fs.ErrNotExist},
+	},
+	"error-message": {
+		err:         assert.AnError,
+		expectError: assert.AnError.Error(),
+	},
+	"error-matcher": {
+		err:         assert.AnError,
+		expectError: gomock.Any(),
+	},
+
+	"mismatch-value": {
+		setup: test.Errorf("%s mismatch:\nDiff (-want, +got):\n%s",
+			"result", gomock.Any()),
+		result: "other",
+		expect: "value",
+	},
+	"mismatch-matcher": {
+		setup: test.Errorf("%s mismatch: want %s, got %#v",
+			"result", gomock.Any(), "value"),
+		result: "value",
+		expect: gomock.Nil(),
+	},
+	"mismatch-error-unexpected": {
+		setup:  test.Errorf("unexpected error: %v", assert.AnError),
+		err:    assert.AnError,
+		result: "value",
+		expect: "value",
+	},
+	"mismatch-error-missing": {
+		setup: test.Errorf("error mismatch: want %s, got %v",
+			assert.AnError.Error(), nil),
+		expectError: assert.AnError,
+	},
+	"mismatch-error": {
+		setup: test.Errorf("error mismatch: want %s, got %v",
+			assert.AnError.Error(), errors.New("other")),
+		err:         errors.New("other"),
+		expectError: assert.AnError,
+	},
+	"mismatch-error-as": {
+		setup: test.Errorf("error mismatch: want %s, got %v",
+			errOpen.Error(), &fs.PathError{Op: "read", Err: fs.ErrNotExist}),
+		err:         &fs.PathError{Op: "read", Err: fs.ErrNotExist},
+		expectError: errOpen,
+	},
+}
+
+func TestRunCheckValue(t *testing.T) {
+	test.Map(t, checkValueTestCases).
+		RunCheck(func(t test.Test, param CheckValueParams) (any, error) {
+			// Given
+			mock.NewMocks(t).Expect(param.setup)
+
+			// When
+			return param.result, param.err
+		})
+}
+
+type CheckFieldParams struct {
+	setup       mock.SetupFunc
+	result      any
+	expectName  string
+	expectValue int
+	expectItems []string
+	expectError error
+	err         error
+}
+
+var checkFieldTestCases = map[string]CheckFieldParams{
+	"fields": {
+		result: CheckResult{
+			Name: "name", Value: 1, items: []string{"a"},
+		},
+		expectName:  "name",
+		expectValue: 1,
+		expectItems: []string{"a"},
+	},
+	"fields-pointer": {
+		result: &CheckResult{
+			Name: "name", Value: 1, items: []string{"a"},
+		},
+		expectName:  "name",
+		expectValue: 1,
+		expectItems: []string{"a"},
+	},
+
+	"mismatch-field": {
+		setup: test.Errorf("%s mismatch:\nDiff (-want, +got):\n%s",
+			"Name", gomock.Any()),
+		result: CheckResult{
+			Name: "other", Value: 1, items: []string{"a"},
+		},
+		expectName:  "name",
+		expectValue: 1,
+		expectItems: []string{"a"},
+	},
+	"nil-result-with-error": {
+		result:      nil,
+		err:         assert.AnError,
+		expectError: assert.AnError,
+	},
+	"nil-pointer-result": {
+		result: (*CheckResult)(nil),
+	},
+
+	"missing-fields": {
+		setup: mock.Chain(
+			test.Errorf("result field not found [%s]: %T", "Name", "value"),
+			test.Errorf("result field not found [%s]: %T", "Value", "value"),
+			test.Errorf("result field not found [%s]: %T", "Items", "value"),
+		),
+		result: "value",
+	},
+}
+
+func TestRunCheckField(t *testing.T) {
+	test.Map(t, checkFieldTestCases).
+		RunCheck(func(t test.Test, param CheckFieldParams) (any, error) {
+			// Given
+			mock.NewMocks(t).Expect(param.setup)
+
+			// When
+			return param.result, param.err
+		})
+}
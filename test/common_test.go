@@ -10,6 +10,13 @@ import (
 	"github.com/tkrop/go-testing/test"
 )
 
+// logHelper is a test helper function logging the given arguments to test
+// the resolution of the log location of the caller.
+func logHelper(t test.Test, args ...any) {
+	t.Helper()
+	t.Log(args...)
+}
+
 // ParamParams is a test parameter type for the test runner to test evaluation
 // of default test parameter names from the test parameter set.
 type ParamParams struct {
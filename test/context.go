@@ -1,10 +1,15 @@
 package test
 
 import (
+	"fmt"
+	"io"
 	"math"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"runtime/debug"
+	"strconv"
+	"strings"
 	gosync "sync"
 	"sync/atomic"
 	"testing"
@@ -93,15 +98,26 @@ func InRun(expect Expect, test Func) Func {
 // to check for expected test failures.
 type Context struct {
 	sync.Synchronizer
-	t        Test
-	wg       sync.WaitGroup
-	mu       gosync.Mutex
-	failed   atomic.Bool
-	deadline time.Time
-	reporter Reporter
-	cleanups []func()
-	expect   Expect
-	parallel bool
+	sync.Tracker
+	t         Test
+	wg        sync.WaitGroup
+	mu        gosync.Mutex
+	failed    atomic.Bool
+	recovered atomic.Bool
+	deadline  time.Time
+	reporter  Reporter
+	cleanups  []func()
+	attached  bool
+	pendings  []func() []string
+	logs      *logBuffer
+	helpers   gosync.Map
+	routine   atomic.Uint64
+	duration  time.Duration
+	allocs    int
+	elapsed   atomic.Int64
+	expect    Expect
+	parallel  bool
+	soft      bool
 }
 
 // New creates a new minimal isolated test context based on the given test
@@ -112,6 +128,7 @@ func New(t Test, parallel bool) *Context {
 		return &Context{
 			t: tx, wg: tx.wg,
 			deadline: tx.deadline,
+			logs:     &logBuffer{},
 			expect:   true,
 			parallel: parallel,
 		}
@@ -124,11 +141,21 @@ func New(t Test, parallel bool) *Context {
 			deadline, _ := t.Deadline()
 			return deadline
 		}(t),
+		logs:     &logBuffer{},
 		expect:   true,
 		parallel: parallel,
 	}
 }
 
+// isolate creates a second isolated test environment on the parent test
+// context with the same parallel mode and test outcome expectation. The
+// environment shares the log buffer to keep the log output together.
+func (t *Context) isolate() *Context {
+	tx := New(t.t, t.parallel).Expect(t.expect)
+	tx.logs = t.logs
+	return tx
+}
+
 // Expect sets up a new test outcome.
 func (t *Context) Expect(expect Expect) *Context {
 	t.t.Helper()
@@ -178,6 +205,31 @@ func (t *Context) StopEarly(time time.Duration) *Context {
 	return t
 }
 
+// Budget sets up a performance budget for the test function. The test fails,
+// if the test function takes longer than the given duration or allocates more
+// than the given number of objects. A negative or zero value is ignored and
+// disables the respective check.
+//
+// **Note:** Allocations are counted process wide. Allocations of concurrently
+// running tests are hence counted as well. Use sequential tests for reliable
+// allocation budgets.
+func (t *Context) Budget(duration time.Duration, allocs int) *Context {
+	t.t.Helper()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.duration, t.allocs = max(duration, 0), max(allocs, 0)
+
+	return t
+}
+
+// Elapsed returns the time the test function has been running. The elapsed
+// time is only available after the test function has finished.
+func (t *Context) Elapsed() time.Duration {
+	return time.Duration(t.elapsed.Load())
+}
+
 // WaitGroup adds wait group to unlock in case of a failure.
 //
 //revive:disable-next-line:waitgroup-by-value // own wrapper interface
@@ -210,9 +262,28 @@ func (t *Context) Reporter(reporter Reporter) {
 	t.reporter = reporter
 }
 
+// Track registers a function providing the pending expectations of a test
+// component, e.g. a mock controller. The pending expectations are reported
+// together with the goroutines of the test function when the test is stopped
+// by its deadline. This method is used by `mock.Mocks` and `gock.Controller`
+// to register their pending mock calls.
+func (t *Context) Track(pending func() []string) {
+	t.t.Helper()
+	if pending == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pendings = append(t.pendings, pending)
+}
+
 // Cleanup is a function called to setup test cleanup after execution. This
 // method is allowing `gomock` to register its `finish` method that reports the
-// missing mock calls.
+// missing mock calls. After the test context is running, the cleanup function
+// is registered directly with the parent test context to keep the order with
+// its cleanup functions, e.g. of `TempDir`.
 func (t *Context) Cleanup(cleanup func()) {
 	t.t.Helper()
 	if cleanup == nil {
@@ -220,9 +291,14 @@ func (t *Context) Cleanup(cleanup func()) {
 	}
 
 	t.mu.Lock()
-	defer t.mu.Unlock()
+	if !t.attached {
+		t.cleanups = append(t.cleanups, cleanup)
+		t.mu.Unlock()
+		return
+	}
+	t.mu.Unlock()
 
-	t.cleanups = append(t.cleanups, cleanup)
+	t.t.Cleanup(cleanup)
 }
 
 // Name delegates the request to the parent test context.
@@ -235,6 +311,12 @@ func (t *Context) Name() string {
 // Helper delegates request to the parent test context.
 func (t *Context) Helper() {
 	t.t.Helper()
+
+	var pcs [1]uintptr
+	if runtime.Callers(2, pcs[:]) > 0 {
+		frame, _ := runtime.CallersFrames(pcs[:]).Next()
+		t.helpers.Store(frame.Function, true)
+	}
 }
 
 // Parallel robustly delegates request to the parent context. It can be called
@@ -313,23 +395,30 @@ func (t *Context) Skipped() bool {
 	return t.t.Skipped()
 }
 
-// Log delegates request to the parent context. It provides a logging function
-// for the test.
+// Log provides a logging function for the test. The log output is buffered
+// and only forwarded to the parent context, if the test result is not
+// according to expectation or the verbose mode is enabled.
 func (t *Context) Log(args ...any) {
 	t.t.Helper()
 
-	t.t.Log(args...)
+	entry := strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+	if !t.logs.add(t.caller() + ": " + entry) {
+		t.t.Log(args...)
+	}
 	if t.reporter != nil {
 		t.reporter.Log(args...)
 	}
 }
 
-// Logf delegates request to the parent context. It provides a logging function
-// for the test.
+// Logf provides a logging function for the test. The log output is buffered
+// and only forwarded to the parent context, if the test result is not
+// according to expectation or the verbose mode is enabled.
 func (t *Context) Logf(format string, args ...any) {
 	t.t.Helper()
 
-	t.t.Logf(format, args...)
+	if !t.logs.add(t.caller() + ": " + fmt.Sprintf(format, args...)) {
+		t.t.Logf(format, args...)
+	}
 	if t.reporter != nil {
 		t.reporter.Logf(format, args...)
 	}
@@ -386,7 +475,7 @@ func (t *Context) Fatal(args ...any) {
 	} else if t.reporter != nil {
 		t.reporter.Fatal(args...)
 	}
-	runtime.Goexit()
+	t.exit()
 }
 
 // Fatalf handles a fatal failure message that immediate aborts of the test
@@ -404,7 +493,7 @@ func (t *Context) Fatalf(format string, args ...any) {
 	} else if t.reporter != nil {
 		t.reporter.Fatalf(format, args...)
 	}
-	runtime.Goexit()
+	t.exit()
 }
 
 // Fail handles a failure message that immediate aborts of the test execution.
@@ -421,7 +510,7 @@ func (t *Context) Fail() {
 	} else if t.reporter != nil {
 		t.reporter.Fail()
 	}
-	runtime.Goexit()
+	t.exit()
 }
 
 // FailNow handles fatal failure notifications without log output that aborts
@@ -439,7 +528,7 @@ func (t *Context) FailNow() {
 	} else if t.reporter != nil {
 		t.reporter.FailNow()
 	}
-	runtime.Goexit()
+	t.exit()
 }
 
 // Failed reports whether the test has failed.
@@ -499,28 +588,48 @@ func (t *Context) Run(test Func) Test {
 
 	// Wait for test to finish or deadline to expire.
 	select {
-	case <-done:
+	case result := <-done:
 		// Panic is already handled by the reporter.
+		if used, ok := result.(usage); ok {
+			t.budget(used.elapsed, used.allocs)
+		}
 	case <-time.After(wait):
-		t.Fatal("stopped by deadline")
+		t.Fatalf("stopped by deadline%s", t.dump())
 	}
 
 	return t
 }
 
+// usage is the resource usage of a regularly finished test function.
+type usage struct {
+	// Elapsed time of the test function.
+	elapsed time.Duration
+	// Number of allocations of the test function.
+	allocs int
+}
+
 // run executes the test function in a safe, detached test environment. The
 // function reports execution failure to the parent test context and unlocks
-// the waiting test context.
+// the waiting test context providing the resource [usage] of the regularly
+// finished test function.
 //
 // The function is supposed to be called in a goroutine.
 func (t *Context) run(test Func, done chan any) {
 	t.t.Helper()
 
+	// Remember goroutine to filter goroutine dump.
+	t.routine.Store(goroutineID())
+
+	var used any
+	start, allocs := time.Now(), t.mallocs()
 	defer func() {
 		t.t.Helper()
 
 		// Unlock the waiting test context.
-		defer func() { done <- nil }()
+		defer func() { done <- used }()
+
+		// Record the elapsed time also for failing tests.
+		t.elapsed.Store(int64(time.Since(start)))
 
 		// Intercept and report panic as a failure.
 		if arg := recover(); arg != nil {
@@ -529,6 +638,57 @@ func (t *Context) run(test Func, done chan any) {
 	}()
 
 	test(t)
+	used = usage{elapsed: time.Since(start), allocs: t.mallocs() - allocs}
+}
+
+// mallocs returns the cumulative count of allocated heap objects, if an
+// allocation budget is set up. Else zero is returned to avoid the costs of
+// reading the memory statistics.
+func (t *Context) mallocs() int {
+	if t.allocs == 0 {
+		return 0
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return int(stats.Mallocs) //nolint:gosec // no overflow expected.
+}
+
+// budget checks the given elapsed time and allocations of a finished test
+// function against the performance budget of the test.
+func (t *Context) budget(elapsed time.Duration, allocs int) {
+	t.t.Helper()
+
+	if t.duration > 0 && elapsed > t.duration {
+		t.Errorf("budget exceeded: duration %v > %v", elapsed, t.duration)
+	}
+	if t.allocs > 0 && allocs > t.allocs {
+		t.Errorf("budget exceeded: allocs %d > %d", allocs, t.allocs)
+	}
+}
+
+// dump creates a report of the pending expectations of the tracked test
+// components and the stack traces of the goroutines spawned by the test
+// function to analyze a test that is stopped by its deadline.
+func (t *Context) dump() string {
+	t.mu.Lock()
+	pendings := append([]func() []string{}, t.pendings...)
+	t.mu.Unlock()
+
+	var builder strings.Builder
+	for _, pending := range pendings {
+		for _, call := range pending() {
+			if builder.Len() == 0 {
+				builder.WriteString("\n\npending expectations:")
+			}
+			builder.WriteString("\n\t" + call)
+		}
+	}
+
+	if stacks := goroutines(t.routine.Load()); len(stacks) != 0 {
+		builder.WriteString("\n\n" + strings.Join(stacks, "\n\n"))
+	}
+	return builder.String()
 }
 
 // register registers the clean up handlers with the parent test context.
@@ -536,14 +696,12 @@ func (t *Context) register() {
 	t.t.Helper()
 
 	// Register cleanup handlers with the parent test context.
-	if c, ok := t.t.(Cleanuper); ok {
-		c.Cleanup(func() {
-			t.t.Helper()
-
-			for i := len(t.cleanups) - 1; i >= 0; i-- {
-				t.cleanups[i]()
-			}
-		})
+	t.mu.Lock()
+	cleanups := t.cleanups
+	t.cleanups, t.attached = nil, true
+	t.mu.Unlock()
+	for _, cleanup := range cleanups {
+		t.t.Cleanup(cleanup)
 	}
 
 	// Register handler to unlocked the waiting test context.
@@ -560,19 +718,81 @@ func (t *Context) finish() {
 	defer t.mu.Unlock()
 
 	if t.t.Skipped() {
+		t.flush(false)
 		return
 	}
 
 	switch t.expect {
 	case Success:
 		if t.failed.Load() {
+			t.flush(true)
 			t.t.Errorf("Expected test to succeed but it failed: %s", t.Name())
+			return
 		}
 	case Failure:
 		if !t.failed.Load() {
+			t.flush(true)
 			t.t.Errorf("Expected test to fail but it succeeded: %s", t.Name())
+			return
 		}
 	}
+	t.flush(false)
+}
+
+// flush forwards the buffered log entries to the parent test context, if the
+// test result is not according to expectation or the verbose mode is enabled.
+// Else the buffered log entries are dropped. Log entries of tests that are
+// expected to fail are labeled as such. After flushing, log entries are
+// directly forwarded to the parent test context.
+func (t *Context) flush(failed bool) {
+	t.t.Helper()
+
+	entries := t.logs.close()
+	if !failed && !verbose() {
+		return
+	}
+
+	label := ""
+	if t.expect == Failure {
+		label = "expected failure: "
+	}
+	for _, entry := range entries {
+		t.output(label + entry)
+	}
+}
+
+// Output returns a writer to the log output of the test, that in contrast to
+// [Context.Log] is not adding the source code location to the log entries.
+// The log output is buffered the same way as [Context.Log].
+func (t *Context) Output() io.Writer {
+	return logWriter{t: t}
+}
+
+// output forwards the given log entry, that already contains its source code
+// location, to the parent test context without adding another location, if
+// the parent test context supports it.
+func (t *Context) output(entry string) {
+	t.t.Helper()
+
+	if out, ok := t.t.(interface{ Output() io.Writer }); ok {
+		_, _ = io.WriteString(out.Output(), entry+"\n")
+	} else {
+		t.t.Log(entry)
+	}
+}
+
+// logWriter is a writer to the log output of a test context.
+type logWriter struct {
+	t *Context
+}
+
+// Write writes the given bytes as log entry to the test context.
+func (w logWriter) Write(data []byte) (int, error) {
+	entry := strings.TrimSuffix(string(data), "\n")
+	if !w.t.logs.add(entry) {
+		w.t.output(entry)
+	}
+	return len(data), nil
 }
 
 // lockOrExit either locks the test mutex or aborts a test in case of a pending
@@ -580,12 +800,20 @@ func (t *Context) finish() {
 func (t *Context) lockOrExit() {
 	t.t.Helper()
 
-	if t.expect == Failure && t.failed.Swap(true) {
+	if t.expect == Failure && t.failed.Swap(true) && !t.soft {
 		runtime.Goexit()
 	}
 	t.mu.Lock()
 }
 
+// exit aborts the test execution after a fatal failure, unless the test
+// context is collecting soft failures.
+func (t *Context) exit() {
+	if !t.soft {
+		runtime.Goexit()
+	}
+}
+
 // unlock unlocks the wait group of the test by consuming the wait group
 // counter completely.
 func (t *Context) unlock() {
@@ -594,3 +822,125 @@ func (t *Context) unlock() {
 	}
 	t.mu.Unlock()
 }
+
+// regexGoroutine is a regular expression to extract the goroutine identifier
+// from the header of a goroutine stack trace.
+var regexGoroutine = regexp.MustCompile(`^goroutine ([0-9]+) `)
+
+// regexCreatedBy is a regular expression to extract the identifier of the
+// parent goroutine from a goroutine stack trace.
+var regexCreatedBy = regexp.MustCompile(
+	`\ncreated by .* in goroutine ([0-9]+)\n`)
+
+// goroutineID returns the identifier of the current goroutine.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	if match := regexGoroutine.FindSubmatch(buf); match != nil {
+		id, _ := strconv.ParseUint(string(match[1]), 10, 64)
+		return id
+	}
+	return 0
+}
+
+// goroutines returns the stack traces of the goroutine with the given
+// identifier and of all goroutines transitively created by this goroutine.
+// The stack traces of all other goroutines are filtered.
+func goroutines(id uint64) []string {
+	if id == 0 {
+		return nil
+	}
+
+	buf := make([]byte, 1<<16)
+	for n := runtime.Stack(buf, true); ; n = runtime.Stack(buf, true) {
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	ids := []uint64{}
+	stacks := map[uint64]string{}
+	parents := map[uint64]uint64{}
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		match := regexGoroutine.FindStringSubmatch(stack)
+		if match == nil {
+			continue
+		}
+		child, _ := strconv.ParseUint(match[1], 10, 64)
+		ids, stacks[child] = append(ids, child), stack
+		if match := regexCreatedBy.FindStringSubmatch(stack + "\n"); match != nil {
+			parents[child], _ = strconv.ParseUint(match[1], 10, 64)
+		}
+	}
+
+	result := []string{}
+	for _, child := range ids {
+		// Follow parents until the test goroutine is reached while the
+		// number of steps is limited by the number of goroutines.
+		for parent, step := child, 0; step <= len(ids); step++ {
+			if parent == id {
+				result = append(result, strings.TrimSpace(stacks[child]))
+				break
+			} else if parent = parents[parent]; parent == 0 {
+				break
+			}
+		}
+	}
+	return result
+}
+
+// logBuffer is a concurrency safe buffer for log entries that allows to hold
+// back the log output of a test until the test has finished.
+type logBuffer struct {
+	mu      gosync.Mutex
+	entries []string
+	closed  bool
+}
+
+// add adds a log entry to the log buffer. If the log buffer is already closed,
+// false is returned to signal that the log entry must be forwarded directly.
+func (b *logBuffer) add(entry string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return false
+	}
+	b.entries = append(b.entries, entry)
+	return true
+}
+
+// close closes the log buffer and returns the buffered log entries.
+func (b *logBuffer) close() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries := b.entries
+	b.entries, b.closed = nil, true
+	return entries
+}
+
+// caller returns the source code location of the caller of the calling
+// method in the format `file:line` skipping the functions that are marked
+// as helper functions via [Context.Helper].
+func (t *Context) caller() string {
+	var pcs [64]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if _, ok := t.helpers.Load(frame.Function); !ok || !more {
+			if frame.File == "" {
+				return "???:0"
+			}
+			return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+	}
+}
+
+// verbose returns whether the verbose mode of the test execution is enabled.
+func verbose() bool {
+	defer func() { _ = recover() }()
+	return testing.Verbose()
+}
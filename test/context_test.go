@@ -1,7 +1,12 @@
 package test_test
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"strings"
+	gosync "sync"
 	"testing"
 	"time"
 
@@ -284,3 +289,206 @@ func TestDeadline(t *testing.T) {
 				})
 		})
 }
+
+// deadlineBlock is a helper blocking until the given channel is closed, that
+// allows to identify a blocked goroutine in the goroutine dump.
+func deadlineBlock(wait chan struct{}, spawn int) {
+	if spawn > 0 {
+		go deadlineBlock(wait, spawn-1)
+	}
+	<-wait
+}
+
+type DeadlineDumpParams struct {
+	spawn  int
+	expect []string
+	reject []string
+}
+
+var deadlineDumpTestCases = map[string]DeadlineDumpParams{
+	"test-goroutine": {
+		spawn: 0,
+		expect: []string{
+			"stopped by deadline\n\npending expectations:\n" +
+				"\t*test.Validator.Fatalf(",
+			"test_test.deadlineBlock(",
+			"test.(*Context).run(",
+		},
+		reject: []string{
+			"test.(*Context).Run(",
+			"test_test.deadlineBlock in goroutine",
+		},
+	},
+	"spawned-goroutines": {
+		spawn: 2,
+		expect: []string{
+			"stopped by deadline\n\npending expectations:\n" +
+				"\t*test.Validator.Fatalf(",
+			"test.(*Context).run(",
+			"test_test.deadlineBlock in goroutine",
+		},
+		reject: []string{
+			"test.(*Context).Run(",
+		},
+	},
+}
+
+func TestDeadlineDump(t *testing.T) {
+	test.Map(t, deadlineDumpTestCases).
+		Run(func(t test.Test, param DeadlineDumpParams) {
+			// Given
+			wait := make(chan struct{})
+			defer close(wait)
+
+			mocks := mock.NewMocks(t).Expect(test.Fatalf("stopped by deadline%s",
+				gomock.Cond(func(dump string) bool {
+					msg := "stopped by deadline" + dump
+					for _, expect := range param.expect {
+						if !strings.Contains(msg, expect) {
+							return false
+						}
+					}
+					for _, reject := range param.reject {
+						if strings.Contains(msg, reject) {
+							return false
+						}
+					}
+					return true
+				})))
+			ctx := test.New(t, !test.Parallel).Expect(test.Success).
+				Timeout(50 * time.Millisecond)
+			ctx.Track(mocks.Pending)
+
+			// When
+			ctx.Run(func(test.Test) {
+				deadlineBlock(wait, param.spawn)
+			})
+		})
+}
+
+// logRecorder is a parent test recording the forwarded log and error output.
+type logRecorder struct {
+	*testing.T
+	mu   gosync.Mutex
+	logs []string
+}
+
+// record records the given log entry replacing the line numbers.
+func (r *logRecorder) record(entry string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, regexLine.ReplaceAllString(entry, ":*:"))
+}
+
+func (r *logRecorder) Log(args ...any) {
+	r.record(fmt.Sprint(args...))
+}
+
+func (r *logRecorder) Logf(format string, args ...any) {
+	r.record(fmt.Sprintf(format, args...))
+}
+
+func (r *logRecorder) Errorf(format string, args ...any) {
+	r.record("error: " + fmt.Sprintf(format, args...))
+}
+
+func (r *logRecorder) Output() io.Writer {
+	return r
+}
+
+func (r *logRecorder) Write(data []byte) (int, error) {
+	r.record(strings.TrimSuffix(string(data), "\n"))
+	return len(data), nil
+}
+
+// regexLine is a regular expression to match line numbers of log entries.
+var regexLine = regexp.MustCompile(`:[0-9]+:`)
+
+type LogBufferParams struct {
+	expect        test.Expect
+	test          test.Func
+	expectLogs    []string
+	expectVerbose []string
+}
+
+var logBufferTestCases = map[string]LogBufferParams{
+	"success-hidden": {
+		expect: test.Success,
+		test: func(t test.Test) {
+			t.Log("log", "message")
+			t.Logf("%s message", "logf")
+		},
+		expectVerbose: []string{
+			"context_test.go:*: log message",
+			"context_test.go:*: logf message",
+		},
+	},
+	"failure-shown": {
+		expect: test.Success,
+		test: func(t test.Test) {
+			t.Log("log", "message")
+			t.Errorf("fail")
+		},
+		expectLogs: []string{
+			"error: fail",
+			"context_test.go:*: log message",
+			"error: Expected test to succeed but it failed: " +
+				"TestLogBuffer/failure-shown",
+		},
+	},
+	"helper-location": {
+		expect: test.Success,
+		test: func(t test.Test) {
+			logHelper(t, "helper", "message")
+			t.Errorf("fail")
+		},
+		expectLogs: []string{
+			"error: fail",
+			"context_test.go:*: helper message",
+			"error: Expected test to succeed but it failed: " +
+				"TestLogBuffer/helper-location",
+		},
+	},
+	"expected-failure-hidden": {
+		expect: test.Failure,
+		test: func(t test.Test) {
+			t.Log("log", "message")
+			t.Errorf("fail")
+		},
+		expectVerbose: []string{
+			"expected failure: context_test.go:*: log message",
+		},
+	},
+	"expected-failure-succeeded": {
+		expect: test.Failure,
+		test: func(t test.Test) {
+			t.Log("log", "message")
+		},
+		expectLogs: []string{
+			"expected failure: context_test.go:*: log message",
+			"error: Expected test to fail but it succeeded: " +
+				"TestLogBuffer/expected-failure-succeeded",
+		},
+	},
+}
+
+func TestLogBuffer(t *testing.T) {
+	for name, param := range logBufferTestCases {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			parent := &logRecorder{T: t}
+			t.Cleanup(func() {
+				// Then
+				expect := param.expectLogs
+				if testing.Verbose() && param.expectVerbose != nil {
+					expect = param.expectVerbose
+				}
+				assert.Equal(t, expect, parent.logs)
+			})
+
+			// When
+			test.New(parent, !test.Parallel).
+				Expect(param.expect).Run(param.test)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Contract runs a reusable behavioral test suite against each implementation
+// of the interface `I` as a matrix of parallel sub tests named after the
+// implementation and the test case. Each cell of the matrix gets its own
+// isolated test context, that is used to create a fresh instance of the
+// implementation via the given factory, so that the factory can also set up
+// the `mock.Mocks` needed by the implementation. This allows to ensure that
+// different implementations, e.g. storage backends and in-memory fakes, are
+// behaving identically.
+//
+// The following code shows a quick example of how to use this function:
+//
+// ```go
+//
+//	func TestStore(t *testing.T) {
+//		test.Contract(t, map[string]func(t test.Test) Store{
+//			"memory": func(t test.Test) Store { return NewMemoryStore() },
+//			"remote": func(t test.Test) Store {
+//				mocks := mock.NewMocks(t).Expect(...)
+//				return NewRemoteStore(mock.Get(mocks, NewMockClient))
+//			},
+//		}, storeContract)
+//	}
+//
+// ```
+func Contract[I any](
+	t *testing.T, impls map[string]func(t Test) I,
+	suite map[string]func(t Test, impl I),
+) {
+	t.Helper()
+
+	names := make([]string, 0, len(impls))
+	for name := range impls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		impl := impls[name]
+		t.Run(strings.ReplaceAll(name, " ", "-"), func(t *testing.T) {
+			t.Helper()
+
+			Map(t, suite).Run(func(t Test, test func(Test, I)) {
+				t.Helper()
+
+				test(t, impl(t))
+			})
+		})
+	}
+}
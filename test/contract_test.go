@@ -0,0 +1,117 @@
+package test_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/test"
+)
+
+// store is the interface to test the contract test suite.
+type store interface {
+	Put(key, value string)
+	Get(key string) (string, bool)
+}
+
+// mapStore is a map based store implementation.
+type mapStore map[string]string
+
+func (s mapStore) Put(key, value string) {
+	s[key] = value
+}
+
+func (s mapStore) Get(key string) (string, bool) {
+	value, ok := s[key]
+	return value, ok
+}
+
+// sliceStore is a slice based store implementation.
+type sliceStore struct {
+	entries [][2]string
+}
+
+func (s *sliceStore) Put(key, value string) {
+	for index, entry := range s.entries {
+		if entry[0] == key {
+			s.entries[index][1] = value
+			return
+		}
+	}
+	s.entries = append(s.entries, [2]string{key, value})
+}
+
+func (s *sliceStore) Get(key string) (string, bool) {
+	for _, entry := range s.entries {
+		if entry[0] == key {
+			return entry[1], true
+		}
+	}
+	return "", false
+}
+
+var storeContract = map[string]func(test.Test, store){
+	"get-missing": func(t test.Test, s store) {
+		// When
+		_, ok := s.Get("key")
+
+		// Then
+		assert.False(t, ok)
+	},
+	"put-get": func(t test.Test, s store) {
+		// Given
+		s.Put("key", "value")
+
+		// When
+		value, ok := s.Get("key")
+
+		// Then
+		assert.True(t, ok)
+		assert.Equal(t, "value", value)
+	},
+	"put-overwrite": func(t test.Test, s store) {
+		// Given
+		s.Put("key", "value")
+		s.Put("key", "other")
+
+		// When
+		value, ok := s.Get("key")
+
+		// Then
+		assert.True(t, ok)
+		assert.Equal(t, "other", value)
+	},
+}
+
+func TestContract(t *testing.T) {
+	mutex, names := sync.Mutex{}, []string{}
+	record := func(t test.Test) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		names = append(names, t.Name())
+	}
+	t.Cleanup(func() {
+		sort.Strings(names)
+		assert.Equal(t, []string{
+			"TestContract/map-store/get-missing",
+			"TestContract/map-store/put-get",
+			"TestContract/map-store/put-overwrite",
+			"TestContract/slice-store/get-missing",
+			"TestContract/slice-store/put-get",
+			"TestContract/slice-store/put-overwrite",
+		}, names)
+	})
+
+	test.Contract(t, map[string]func(test.Test) store{
+		"map store": func(t test.Test) store {
+			record(t)
+			return mapStore{}
+		},
+		"slice-store": func(t test.Test) store {
+			record(t)
+			return &sliceStore{}
+		},
+	}, storeContract)
+}
@@ -0,0 +1,382 @@
+package test
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tkrop/go-testing/internal/reflect"
+)
+
+// ErrNoEnumConsts is an error for enum types without constants.
+var ErrNoEnumConsts = errors.New("no enum constants")
+
+// NewErrNoEnumConsts creates a new error for enum types without constants.
+func NewErrNoEnumConsts(rtype reflect.Type) error {
+	return fmt.Errorf("%w [type: %v]", ErrNoEnumConsts, rtype)
+}
+
+// ErrEnumLoading is an error for enum types whose package cannot be loaded.
+var ErrEnumLoading = errors.New("enum loading")
+
+// NewErrEnumLoading creates a new error for enum types whose package cannot be
+// loaded due to the given error.
+func NewErrEnumLoading(rtype reflect.Type, err error) error {
+	return fmt.Errorf("%w [type: %v]: %w", ErrEnumLoading, rtype, err)
+}
+
+// DefaultEnumString is the default string representation of enum values that
+// are out of range.
+const DefaultEnumString = "Unknown"
+
+// EnumParams provides test parameters for testing the `String` method and the
+// optional `Parse` function and `UnmarshalText` method of enum types, i.e.
+// named types with a set of constants.
+type EnumParams[T fmt.Stringer] struct {
+	// Value is the enum constant or the out of range value to test.
+	Value T
+	// Values are all enum constants of the enum type.
+	Values []T
+	// Default is the string representation of out of range values.
+	Default string
+	// OutOfRange defines whether the value is an out of range value.
+	OutOfRange bool
+	// Parse is an optional function to parse the string representation.
+	Parse func(string) (T, error)
+}
+
+// EnumTestCases creates the test cases for all constants of the enum type `T`
+// discovered by parsing the package of the type. For each constant a test case
+// named after the constant is created. For integer enum types an additional
+// `out-of-range` test case with the value following the largest constant is
+// created, that is expected to yield the `DefaultEnumString`. The optional
+// parse function is used to check the round trip of the string representation.
+// The function panics, if no constants of the type are found, e.g. if the
+// source files of the package are not available, or if the package of the type
+// cannot be loaded.
+func EnumTestCases[T fmt.Stringer](
+	parse func(string) (T, error),
+) map[string]EnumParams[T] {
+	rtype := reflect.TypeOf((*T)(nil)).Elem()
+	names, values := enumConsts(rtype)
+	if len(values) == 0 {
+		panic(NewErrNoEnumConsts(rtype))
+	}
+
+	consts := make([]T, 0, len(values))
+	cases := make(map[string]EnumParams[T], len(values)+1)
+	for index, value := range values {
+		value := reflect.ValueOf(value).Convert(rtype).Interface().(T)
+		cases[constToTestName(names[index])] = EnumParams[T]{Value: value}
+		consts = append(consts, value)
+	}
+
+	if value, ok := enumOutOfRange(values); ok {
+		cases["out-of-range"] = EnumParams[T]{
+			Value:      reflect.ValueOf(value).Convert(rtype).Interface().(T),
+			OutOfRange: true,
+		}
+	}
+
+	for name, param := range cases {
+		param.Values, param.Default, param.Parse =
+			consts, DefaultEnumString, parse
+		cases[name] = param
+	}
+	return cases
+}
+
+// Enum provides a test function that tests the `String` method and the
+// optional `Parse` function and `UnmarshalText` method of enum types. For enum
+// constants the test function verifies that the string representation is
+// unique and not the default string representation, and that the string
+// representation is parsed and unmarshaled to the same value. For out of
+// range values the test function verifies that the default string
+// representation is returned.
+//
+// The following code shows a quick example of how to use this function in a
+// tests:
+//
+// ```go
+//
+//	func TestMyEnum(t *testing.T) {
+//		test.Map(t, test.EnumTestCases(ParseMyEnum)).
+//			Run(test.Enum[MyEnum])
+//	}
+//
+// ```
+func Enum[T fmt.Stringer](t Test, p EnumParams[T]) {
+	// When
+	str := p.Value.String()
+
+	// Then
+	if p.OutOfRange {
+		if str != p.Default {
+			t.Errorf("enum out of range string [%T(%v)]: want %q, got %q",
+				p.Value, enumRaw(p.Value), p.Default, str)
+		}
+		return
+	} else if str == p.Default {
+		t.Errorf("enum default string [%T(%v)]: %q",
+			p.Value, enumRaw(p.Value), str)
+	}
+
+	for _, value := range p.Values {
+		if any(value) != any(p.Value) && value.String() == str {
+			t.Errorf("enum string not unique [%T(%v)]: %q",
+				p.Value, enumRaw(p.Value), str)
+		}
+	}
+
+	if p.Parse != nil {
+		if value, err := p.Parse(str); err != nil {
+			t.Errorf("enum parse failed [%T(%v)]: %v",
+				p.Value, enumRaw(p.Value), err)
+		} else if any(value) != any(p.Value) {
+			t.Errorf("enum parse mismatch [%T(%v)]: got %v",
+				p.Value, enumRaw(p.Value), enumRaw(value))
+		}
+	}
+
+	value := reflect.New(reflect.TypeOf(p.Value))
+	if unmarshaler, ok := value.Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(str)); err != nil {
+			t.Errorf("enum unmarshal failed [%T(%v)]: %v",
+				p.Value, enumRaw(p.Value), err)
+		} else if value.Elem().Interface() != any(p.Value) {
+			t.Errorf("enum unmarshal mismatch [%T(%v)]: got %v",
+				p.Value, enumRaw(p.Value),
+				enumRaw(value.Elem().Interface()))
+		}
+	}
+}
+
+// enumConsts returns the names and values of all constants of the given enum
+// type in order of their values by type checking the package of the type. The
+// function panics, if the package cannot be read, parsed, or type checked.
+func enumConsts(rtype reflect.Type) ([]string, []any) {
+	fset, files, err := enumFiles(rtype)
+	if err != nil {
+		panic(NewErrEnumLoading(rtype, err))
+	}
+
+	imports := newEnumImporter(fset, files)
+	errs := []error{}
+	config := &types.Config{Importer: imports, Error: func(err error) {
+		if terr, ok := err.(types.Error); !ok || imports.relevant(terr.Pos) {
+			errs = append(errs, err)
+		}
+	}}
+	pkg, _ := config.Check(rtype.PkgPath(), fset, files, nil)
+	if len(errs) != 0 {
+		panic(NewErrEnumLoading(rtype, errors.Join(errs...)))
+	}
+
+	consts := []*types.Const{}
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		if cnst, ok := scope.Lookup(name).(*types.Const); ok &&
+			cnst.Val().Kind() != constant.Unknown &&
+			enumType(cnst.Type(), rtype.PkgPath(), rtype.Name()) {
+			consts = append(consts, cnst)
+		}
+	}
+	sort.SliceStable(consts, func(i, j int) bool {
+		return constant.Compare(consts[i].Val(), token.LSS, consts[j].Val())
+	})
+
+	names := make([]string, 0, len(consts))
+	values := make([]any, 0, len(consts))
+	for _, cnst := range consts {
+		names = append(names, cnst.Name())
+		values = append(values, enumValue(cnst.Val()))
+	}
+	return names, values
+}
+
+// enumFiles parses the source files of the package of the given enum type.
+// The package directory and name are derived from the source file defining
+// the `String` method of the enum type, that must be part of the package.
+func enumFiles(rtype reflect.Type) (*token.FileSet, []*ast.File, error) {
+	fset := token.NewFileSet()
+	method, ok := rtype.MethodByName("String")
+	if !ok {
+		return fset, nil, nil
+	}
+	pc := method.Func.Pointer()
+	path, _ := runtime.FuncForPC(pc).FileLine(pc)
+	source, err := parser.ParseFile(fset, path, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return fset, nil, err
+	}
+
+	dir := filepath.Dir(path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fset, nil, err
+	}
+	files := []*ast.File{}
+	for _, entry := range entries {
+		if ok, err := build.Default.MatchFile(dir, entry.Name()); err != nil {
+			return fset, nil, err
+		} else if !ok {
+			continue
+		}
+		file, err := parser.ParseFile(fset,
+			filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fset, nil, err
+		} else if file.Name.Name == source.Name.Name {
+			files = append(files, file)
+		}
+	}
+	return fset, files, nil
+}
+
+// enumImporter is an importer resolving only the packages referenced by
+// constant declarations from source, since the constants of enum types can be
+// evaluated without the other imported packages. Skipping these keeps type
+// checking fast, but creates errors unrelated to the constants, that need to
+// be ignored.
+type enumImporter struct {
+	// Source importer used for the referenced packages.
+	source types.Importer
+	// Import paths referenced by constant declarations.
+	paths map[string]bool
+	// Nodes of the constant and resolved import declarations.
+	nodes []ast.Node
+}
+
+// newEnumImporter creates an importer for the given files, that resolves only
+// the packages referenced by constant declarations from source.
+func newEnumImporter(fset *token.FileSet, files []*ast.File) *enumImporter {
+	imports := &enumImporter{
+		source: importer.ForCompiler(fset, "source", nil),
+		paths:  map[string]bool{},
+	}
+
+	for _, file := range files {
+		names := map[string]string{}
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			if spec.Name != nil {
+				names[spec.Name.Name] = path
+			} else {
+				names[pathpkg.Base(path)] = path
+			}
+		}
+
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.CONST {
+				imports.nodes = append(imports.nodes, decl)
+				ast.Inspect(decl, func(node ast.Node) bool {
+					if sel, ok := node.(*ast.SelectorExpr); ok {
+						if ident, ok := sel.X.(*ast.Ident); ok &&
+							names[ident.Name] != "" {
+							imports.paths[names[ident.Name]] = true
+						}
+					}
+					return true
+				})
+			}
+		}
+
+		for _, spec := range file.Imports {
+			if path, _ := strconv.Unquote(spec.Path.Value); imports.paths[path] {
+				imports.nodes = append(imports.nodes, spec)
+			}
+		}
+	}
+	return imports
+}
+
+// relevant returns whether an error at the given position is relevant for
+// the constants, i.e. whether it is part of a constant declaration or of a
+// resolved import declaration.
+func (i *enumImporter) relevant(pos token.Pos) bool {
+	for _, node := range i.nodes {
+		if node.Pos() <= pos && pos < node.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// errEnumImport is the error returned when importing packages, that are not
+// referenced by constant declarations, while type checking the package of
+// enum types.
+var errEnumImport = errors.New("import not supported")
+
+// Import resolves the given import path from source, if it is referenced by
+// constant declarations, and fails otherwise.
+func (i *enumImporter) Import(path string) (*types.Package, error) {
+	if i.paths[path] {
+		return i.source.Import(path)
+	}
+	return nil, errEnumImport
+}
+
+// enumType returns whether the given type is the named enum type.
+func enumType(ctype types.Type, path, name string) bool {
+	named, ok := ctype.(*types.Named)
+	return ok && named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == path && named.Obj().Name() == name
+}
+
+// enumValue converts the given constant value to a plain value.
+func enumValue(value constant.Value) any {
+	switch value.Kind() {
+	case constant.Int:
+		if val, ok := constant.Int64Val(value); ok {
+			return val
+		}
+		val, _ := constant.Uint64Val(value)
+		return val
+	case constant.Float:
+		val, _ := constant.Float64Val(value)
+		return val
+	case constant.String:
+		return constant.StringVal(value)
+	case constant.Bool:
+		return constant.BoolVal(value)
+	default:
+		return value.ExactString()
+	}
+}
+
+// enumOutOfRange returns the out of range value following the largest integer
+// enum constant and whether such a value exists.
+func enumOutOfRange(values []any) (any, bool) {
+	if len(values) == 0 {
+		return nil, false
+	} else if value, ok := values[len(values)-1].(int64); ok {
+		return value + 1, true
+	}
+	return nil, false
+}
+
+// enumRaw returns the raw value of the given enum value without the enum type
+// to print the value without calling the `String` method.
+func enumRaw(value any) any {
+	return reflect.ArgOf(reflect.ValueOf(value))
+}
+
+// constToTestName converts a constant name into a human readable test case
+// name, i.e. a hyphen-separated lower-case string.
+func constToTestName(name string) string {
+	return strings.TrimSuffix(toTestName(name), "-")
+}
@@ -0,0 +1,306 @@
+package test_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+// errEnumParse is the error returned when parsing an enum value fails.
+var errEnumParse = errors.New("enum parse failure")
+
+// enumColor is a valid integer enum type.
+type enumColor int
+
+const (
+	// colorRed is the red color.
+	colorRed enumColor = 1
+	// colorGreen is the green color.
+	colorGreen enumColor = 2
+	// colorBlue is the blue color.
+	colorBlue enumColor = 3
+)
+
+func (c enumColor) String() string {
+	switch c {
+	case colorRed:
+		return "Red"
+	case colorGreen:
+		return "Green"
+	case colorBlue:
+		return "Blue"
+	default:
+		return "Unknown"
+	}
+}
+
+func (c *enumColor) UnmarshalText(text []byte) (err error) {
+	*c, err = parseColor(string(text))
+	return err
+}
+
+func parseColor(str string) (enumColor, error) {
+	for _, color := range []enumColor{colorRed, colorGreen, colorBlue} {
+		if color.String() == str {
+			return color, nil
+		}
+	}
+	return 0, errEnumParse
+}
+
+// enumBroken is a broken integer enum type.
+type enumBroken uint8
+
+const (
+	// brokenNone is missing a string representation.
+	brokenNone enumBroken = 0
+	// brokenOne has the same string representation as brokenTwo.
+	brokenOne enumBroken = 1
+	// brokenTwo has the same string representation as brokenOne.
+	brokenTwo enumBroken = 2
+)
+
+func (b enumBroken) String() string {
+	switch b {
+	case brokenNone:
+		return "Unknown"
+	case brokenOne, brokenTwo:
+		return "Some"
+	default:
+		return "Other"
+	}
+}
+
+func (b *enumBroken) UnmarshalText([]byte) error {
+	*b = brokenOne
+	return nil
+}
+
+func parseBroken(string) (enumBroken, error) {
+	return brokenOne, nil
+}
+
+func parseBrokenFail(string) (enumBroken, error) {
+	return 0, errEnumParse
+}
+
+// enumName is a valid string enum type.
+type enumName string
+
+const (
+	// nameAlpha is the alpha name.
+	nameAlpha enumName = "alpha"
+	// nameBeta is the beta name.
+	nameBeta enumName = "beta"
+)
+
+func (n enumName) String() string {
+	return string(n)
+}
+
+// enumDelay is a valid integer enum type with constants using imports.
+type enumDelay time.Duration
+
+const (
+	// delayShort is the short delay.
+	delayShort = enumDelay(time.Millisecond)
+	// delayLong is the long delay.
+	delayLong = enumDelay(time.Second)
+)
+
+func (d enumDelay) String() string {
+	switch d {
+	case delayShort:
+		return "Short"
+	case delayLong:
+		return "Long"
+	default:
+		return "Unknown"
+	}
+}
+
+type EnumCasesParams struct {
+	call        func() any
+	expect      any
+	expectPanic error
+}
+
+var enumTestCasesTestCases = map[string]EnumCasesParams{
+	"color": {
+		call: func() any {
+			return test.EnumTestCases[enumColor](nil)
+		},
+		expect: map[string]test.EnumParams[enumColor]{
+			"color-red": {
+				Value:   colorRed,
+				Values:  []enumColor{colorRed, colorGreen, colorBlue},
+				Default: test.DefaultEnumString,
+			},
+			"color-green": {
+				Value:   colorGreen,
+				Values:  []enumColor{colorRed, colorGreen, colorBlue},
+				Default: test.DefaultEnumString,
+			},
+			"color-blue": {
+				Value:   colorBlue,
+				Values:  []enumColor{colorRed, colorGreen, colorBlue},
+				Default: test.DefaultEnumString,
+			},
+			"out-of-range": {
+				Value:      enumColor(4),
+				Values:     []enumColor{colorRed, colorGreen, colorBlue},
+				Default:    test.DefaultEnumString,
+				OutOfRange: true,
+			},
+		},
+	},
+	"name": {
+		call: func() any {
+			return test.EnumTestCases[enumName](nil)
+		},
+		expect: map[string]test.EnumParams[enumName]{
+			"name-alpha": {
+				Value:   nameAlpha,
+				Values:  []enumName{nameAlpha, nameBeta},
+				Default: test.DefaultEnumString,
+			},
+			"name-beta": {
+				Value:   nameBeta,
+				Values:  []enumName{nameAlpha, nameBeta},
+				Default: test.DefaultEnumString,
+			},
+		},
+	},
+	"delay": {
+		call: func() any {
+			return test.EnumTestCases[enumDelay](nil)
+		},
+		expect: map[string]test.EnumParams[enumDelay]{
+			"delay-short": {
+				Value:   delayShort,
+				Values:  []enumDelay{delayShort, delayLong},
+				Default: test.DefaultEnumString,
+			},
+			"delay-long": {
+				Value:   delayLong,
+				Values:  []enumDelay{delayShort, delayLong},
+				Default: test.DefaultEnumString,
+			},
+			"out-of-range": {
+				Value:      delayLong + 1,
+				Values:     []enumDelay{delayShort, delayLong},
+				Default:    test.DefaultEnumString,
+				OutOfRange: true,
+			},
+		},
+	},
+	"no-consts": {
+		call: func() any {
+			return test.EnumTestCases[test.Report](nil)
+		},
+		expectPanic: test.NewErrNoEnumConsts(
+			reflect.TypeOf(test.Report{})),
+	},
+}
+
+func TestEnumTestCases(t *testing.T) {
+	test.Map(t, enumTestCasesTestCases).
+		Run(func(t test.Test, param EnumCasesParams) {
+			// When
+			cases := param.call()
+
+			// Then
+			assert.Equal(t, param.expect, cases)
+		})
+}
+
+func TestEnumColor(t *testing.T) {
+	test.Map(t, test.EnumTestCases(parseColor)).
+		Run(test.Enum[enumColor])
+}
+
+func TestEnumName(t *testing.T) {
+	test.Map(t, test.EnumTestCases[enumName](nil)).
+		Run(test.Enum[enumName])
+}
+
+// brokenValues are all constants of the broken enum type.
+var brokenValues = []enumBroken{brokenNone, brokenOne, brokenTwo}
+
+type EnumParams struct {
+	test.EnumParams[enumBroken]
+	setup mock.SetupFunc
+}
+
+var enumTestCases = map[string]EnumParams{
+	"default-string": {
+		EnumParams: test.EnumParams[enumBroken]{
+			Value:   brokenNone,
+			Values:  brokenValues,
+			Default: test.DefaultEnumString,
+			Parse:   parseBroken,
+		},
+		setup: mock.Chain(
+			test.Errorf("enum default string [%T(%v)]: %q",
+				brokenNone, uint8(0), "Unknown"),
+			test.Errorf("enum parse mismatch [%T(%v)]: got %v",
+				brokenNone, uint8(0), uint8(1)),
+			test.Errorf("enum unmarshal mismatch [%T(%v)]: got %v",
+				brokenNone, uint8(0), uint8(1)),
+		),
+	},
+	"not-unique": {
+		EnumParams: test.EnumParams[enumBroken]{
+			Value:   brokenOne,
+			Values:  brokenValues,
+			Default: test.DefaultEnumString,
+			Parse:   parseBroken,
+		},
+		setup: test.Errorf("enum string not unique [%T(%v)]: %q",
+			brokenOne, uint8(1), "Some"),
+	},
+	"parse-failed": {
+		EnumParams: test.EnumParams[enumBroken]{
+			Value:   brokenOne,
+			Values:  []enumBroken{brokenOne},
+			Default: test.DefaultEnumString,
+			Parse:   parseBrokenFail,
+		},
+		setup: test.Errorf("enum parse failed [%T(%v)]: %v",
+			brokenOne, uint8(1), errEnumParse),
+	},
+	"out-of-range": {
+		EnumParams: test.EnumParams[enumBroken]{
+			Value:      enumBroken(3),
+			Values:     brokenValues,
+			Default:    test.DefaultEnumString,
+			OutOfRange: true,
+		},
+		setup: test.Errorf("enum out of range string [%T(%v)]: want %q, got %q",
+			enumBroken(3), uint8(3), "Unknown", "Other"),
+	},
+	"single-value": {
+		EnumParams: test.EnumParams[enumBroken]{
+			Value:   brokenOne,
+			Values:  []enumBroken{brokenOne},
+			Default: "Other",
+		},
+	},
+}
+
+func TestEnum(t *testing.T) {
+	test.Map(t, enumTestCases).
+		Run(func(t test.Test, param EnumParams) {
+			// Given
+			mock.NewMocks(t).Expect(param.setup)
+
+			// When
+			test.Enum(t, param.EnumParams)
+		})
+}
@@ -0,0 +1,330 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/txtar"
+
+	"github.com/tkrop/go-testing/mock"
+)
+
+const (
+	// DefaultFileMode is the default file mode used for regular files created
+	// by [FS] if no explicit file mode is provided.
+	DefaultFileMode fs.FileMode = 0o644
+	// DefaultDirMode is the default file mode used for directories created by
+	// [FS] if no explicit file mode is provided.
+	DefaultDirMode fs.FileMode = 0o755
+)
+
+// ErrInvalidFSEntry is an error for file system entries that are escaping the
+// file system tree or are providing data for a directory.
+var ErrInvalidFSEntry = errors.New("invalid file system entry")
+
+// NewErrInvalidFSEntry creates a new invalid file system entry error for the
+// entry with the given name and the given reason.
+func NewErrInvalidFSEntry(name, reason string) error {
+	return fmt.Errorf("%w [name: %s]: %s", ErrInvalidFSEntry, name, reason)
+}
+
+// FSEntry describes a single entry of a declarative file system tree that is
+// either a regular file, a directory, or a symbolic link.
+type FSEntry struct {
+	// Data is the content of a regular file.
+	Data string
+	// Mode is the file mode of the entry consisting of the permission bits
+	// and the type bits. A directory is signaled by [fs.ModeDir]. If the
+	// permission bits are zero, the default file modes are used on creation
+	// and ignored on comparison.
+	Mode fs.FileMode
+	// Link is the target of a symbolic link. If the link is set, the entry is
+	// created as symbolic link and the data and mode are ignored.
+	Link string
+	// ModTime is the modification time of the entry. If the time is zero, the
+	// time is not changed on creation and ignored on comparison. Symbolic
+	// links are not supporting modification times.
+	ModTime time.Time
+}
+
+// FSSpec is a declarative file system tree specification mapping the slash
+// separated relative paths to the file system entries.
+type FSSpec map[string]FSEntry
+
+// FS materializes the given file system tree specification in a new temporary
+// directory provided by [Test.TempDir] and returns the path of the directory.
+// The specification can be provided in the following formats:
+//
+//   - `map[string]string` mapping paths to file contents, where a path with a
+//     trailing slash (`/`) declares an (empty) directory,
+//   - [FSSpec] allowing to declare permissions, symbolic links, as well as
+//     modification times,
+//   - [*txtar.Archive] mapping the archive files to the tree, and
+//   - [fs.FS] copying the file system content including modes and times.
+//
+// Parent directories are always created implicitly using [DefaultDirMode].
+// Restricted directory modes are relaxed again on test cleanup to allow the
+// removal of the temporary directory. Entries with absolute paths, escaping
+// paths, or data for directories are rejected with [ErrInvalidFSEntry].
+func FS(t Test, spec any) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	entries := fsEntries(t, spec)
+
+	dirs := []string{}
+	t.Cleanup(func() {
+		for _, dir := range dirs {
+			if info, err := os.Lstat(dir); err == nil {
+				_ = os.Chmod(dir, info.Mode().Perm()|0o700)
+			}
+		}
+	})
+
+	paths := fsPaths(entries)
+	for _, name := range paths {
+		fsCreate(t, dir, name, entries[name])
+	}
+
+	// Apply directory modes and modification times in reverse order to
+	// ensure that restricted directories and times are not affected by
+	// creating their children.
+	for i := len(paths) - 1; i >= 0; i-- {
+		entry := entries[paths[i]]
+		if entry.Link != "" {
+			continue
+		}
+
+		file := filepath.Join(dir, filepath.FromSlash(paths[i]))
+		if perm := entry.Mode.Perm(); entry.Mode.IsDir() && perm != 0 {
+			dirs = append(dirs, file)
+			require.NoError(t, os.Chmod(file, perm))
+		}
+		if !entry.ModTime.IsZero() {
+			require.NoError(t, os.Chtimes(file, entry.ModTime, entry.ModTime))
+		}
+	}
+
+	return dir
+}
+
+// AssertFS asserts that the directory tree at the given path is matching the
+// expected file system tree specification. The expected specification accepts
+// the same formats as [FS]. Parent directories are expected implicitly. File
+// modes and modification times are only compared when they are provided in
+// the specification. On mismatch, the failure shows a unified diff of the
+// expected and the actual tree.
+func AssertFS(t Test, dir string, expect any) bool {
+	t.Helper()
+
+	want := fsEntries(t, expect)
+	got := fsRead(t, os.DirFS(dir))
+
+	wstr, gstr := fsRender(want, want), fsRender(got, want)
+	if wstr != gstr {
+		t.Errorf("file system mismatch [%s]:\n%s", dir,
+			mock.NewDiffConfig().Diff(wstr, gstr))
+		return false
+	}
+	return true
+}
+
+// fsEntries converts the given file system tree specification into a file
+// system tree specification that contains all implicit parent directories.
+func fsEntries(t Test, spec any) FSSpec {
+	t.Helper()
+
+	entries := FSSpec{}
+	switch spec := spec.(type) {
+	case map[string]string:
+		for name, data := range spec {
+			if strings.HasSuffix(name, "/") {
+				entries[name] = FSEntry{Data: data, Mode: fs.ModeDir}
+			} else {
+				entries[name] = FSEntry{Data: data}
+			}
+		}
+	case FSSpec:
+		for name, entry := range spec {
+			entries[name] = entry
+		}
+	case *txtar.Archive:
+		for _, file := range spec.Files {
+			entries[file.Name] = FSEntry{Data: string(file.Data)}
+		}
+	case fstest.MapFS:
+		entries = fsRead(t, spec)
+		// Synthesized parent directories are not providing any modes.
+		for name, entry := range entries {
+			if _, ok := spec[name]; !ok && entry.Mode.IsDir() {
+				entries[name] = FSEntry{Mode: fs.ModeDir}
+			}
+		}
+	case fs.FS:
+		entries = fsRead(t, spec)
+	default:
+		panic(NewErrInvalidType(spec))
+	}
+
+	for name, entry := range entries {
+		fsValidate(name, entry)
+	}
+	return fsParents(entries)
+}
+
+// fsValidate validates the given file system entry with the given name. The
+// entry must neither escape the file system tree, i.e. the name must be
+// relative and not lead outside via `..`, nor provide data for a directory.
+func fsValidate(name string, entry FSEntry) {
+	clean := path.Clean(strings.TrimSuffix(name, "/"))
+	switch {
+	case path.IsAbs(name) || filepath.IsAbs(filepath.FromSlash(name)):
+		panic(NewErrInvalidFSEntry(name, "absolute path"))
+	case clean == ".." || strings.HasPrefix(clean, "../"):
+		panic(NewErrInvalidFSEntry(name, "escaping path"))
+	case entry.Mode.IsDir() && entry.Data != "":
+		panic(NewErrInvalidFSEntry(name, "directory with data"))
+	}
+}
+
+// fsEntry reads the file system entry with the given name from given file
+// system.
+func fsEntry(fsys fs.FS, name string) (FSEntry, error) {
+	info, err := fs.Lstat(fsys, name)
+	if err != nil {
+		return FSEntry{}, err //nolint:wrapcheck // transparent wrapper.
+	}
+
+	entry := FSEntry{Mode: info.Mode(), ModTime: info.ModTime()}
+	switch {
+	case info.Mode()&fs.ModeSymlink != 0:
+		entry.Link, err = fs.ReadLink(fsys, name)
+		entry.Mode, entry.ModTime = fs.ModeSymlink, time.Time{}
+	case info.IsDir():
+	default:
+		var data []byte
+		data, err = fs.ReadFile(fsys, name)
+		entry.Data = string(data)
+	}
+	return entry, err //nolint:wrapcheck // transparent wrapper.
+}
+
+// fsParents normalizes the path names of the given file system entries and
+// adds the missing parent directories.
+func fsParents(entries FSSpec) FSSpec {
+	result := FSSpec{}
+	for name, entry := range entries {
+		name = path.Clean(strings.TrimSuffix(name, "/"))
+		if entry.Link != "" {
+			entry.Mode = fs.ModeSymlink
+		}
+		result[name] = entry
+
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			if _, ok := result[dir]; !ok {
+				result[dir] = FSEntry{Mode: fs.ModeDir}
+			}
+		}
+	}
+
+	// Ensure that explicitly declared directories are not overwritten.
+	for name, entry := range entries {
+		name = path.Clean(strings.TrimSuffix(name, "/"))
+		if entry.Mode.IsDir() {
+			result[name] = entry
+		}
+	}
+	return result
+}
+
+// fsPaths returns the sorted paths of the given file system entries ensuring
+// that parent directories are listed before their children.
+func fsPaths(entries FSSpec) []string {
+	paths := make([]string, 0, len(entries))
+	for name := range entries {
+		paths = append(paths, name)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// fsCreate creates the given file system entry with the given name in the
+// given directory.
+func fsCreate(t Test, dir, name string, entry FSEntry) {
+	t.Helper()
+
+	file := filepath.Join(dir, filepath.FromSlash(name))
+	switch {
+	case entry.Link != "":
+		require.NoError(t, os.Symlink(filepath.FromSlash(entry.Link), file))
+	case entry.Mode.IsDir():
+		require.NoError(t, os.MkdirAll(file, DefaultDirMode))
+	default:
+		perm := entry.Mode.Perm()
+		if perm == 0 {
+			perm = DefaultFileMode
+		}
+		require.NoError(t, os.WriteFile(file, []byte(entry.Data), perm))
+		require.NoError(t, os.Chmod(file, perm))
+	}
+}
+
+// fsRead reads the given file system into a file system tree specification.
+func fsRead(t Test, fsys fs.FS) FSSpec {
+	t.Helper()
+
+	entries := FSSpec{}
+	require.NoError(t, fs.WalkDir(fsys, ".",
+		func(name string, _ fs.DirEntry, err error) error {
+			if err != nil || name == "." {
+				return err
+			}
+			entry, err := fsEntry(fsys, name)
+			entries[name] = entry
+			return err
+		}))
+	return entries
+}
+
+// fsRender renders the given file system entries into a txtar like text
+// representation used for creating a diff. The file modes and modification
+// times are only rendered if they are provided by the reference entries.
+func fsRender(entries, refs FSSpec) string {
+	var builder strings.Builder
+	for _, name := range fsPaths(entries) {
+		entry, ref := entries[name], refs[name]
+
+		builder.WriteString("-- " + name)
+		switch {
+		case entry.Mode&fs.ModeSymlink != 0:
+			builder.WriteString(" -> " + entry.Link)
+		case entry.Mode.IsDir():
+			builder.WriteString("/")
+		}
+		if ref.Mode.Perm() != 0 && entry.Link == "" {
+			builder.WriteString(" [" + entry.Mode.Perm().String() + "]")
+		}
+		if !ref.ModTime.IsZero() && entry.Link == "" {
+			builder.WriteString(" [" + entry.ModTime.UTC().
+				Format(time.RFC3339Nano) + "]")
+		}
+		builder.WriteString(" --\n")
+
+		if entry.Data != "" {
+			builder.WriteString(entry.Data)
+			if !strings.HasSuffix(entry.Data, "\n") {
+				builder.WriteString("\n")
+			}
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,216 @@
+package test_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/tools/txtar"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+// modTime is a fixed modification time used for testing.
+var modTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type FSParams struct {
+	setup  mock.SetupFunc
+	spec   any
+	expect any
+	check  func(t test.Test, dir string)
+}
+
+var fsTestCases = map[string]FSParams{
+	"map-empty": {
+		spec:   map[string]string{},
+		expect: map[string]string{},
+	},
+	"map-files": {
+		spec: map[string]string{
+			"a.txt":     "alpha",
+			"dir/b.txt": "beta\n",
+		},
+		expect: map[string]string{
+			"a.txt":     "alpha",
+			"dir/b.txt": "beta\n",
+		},
+	},
+	"map-empty-dir": {
+		spec:   map[string]string{"empty/": ""},
+		expect: map[string]string{"empty/": ""},
+	},
+	"spec-modes": {
+		spec: test.FSSpec{
+			"bin/run": {Data: "#!/bin/sh", Mode: 0o750},
+			"secret":  {Data: "key", Mode: 0o600},
+			"dir":     {Mode: fs.ModeDir | 0o700},
+		},
+		expect: test.FSSpec{
+			"bin/run": {Data: "#!/bin/sh", Mode: 0o750},
+			"secret":  {Data: "key", Mode: 0o600},
+			"dir":     {Mode: fs.ModeDir | 0o700},
+		},
+		check: func(t test.Test, dir string) {
+			info, err := os.Stat(filepath.Join(dir, "secret"))
+			require.NoError(t, err)
+			assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+		},
+	},
+	"spec-read-only-dir": {
+		spec: test.FSSpec{
+			"dir/":      {Mode: fs.ModeDir | 0o555},
+			"dir/a.txt": {Data: "alpha"},
+		},
+		expect: test.FSSpec{
+			"dir/":      {Mode: fs.ModeDir | 0o555},
+			"dir/a.txt": {Data: "alpha"},
+		},
+	},
+	"spec-symlink": {
+		spec: test.FSSpec{
+			"target.txt": {Data: "target"},
+			"link.txt":   {Link: "target.txt"},
+		},
+		expect: test.FSSpec{
+			"target.txt": {Data: "target"},
+			"link.txt":   {Link: "target.txt"},
+		},
+		check: func(t test.Test, dir string) {
+			data, err := os.ReadFile(filepath.Join(dir, "link.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "target", string(data))
+		},
+	},
+	"spec-mod-time": {
+		spec: test.FSSpec{
+			"dir/file.txt": {Data: "data", ModTime: modTime},
+			"dir":          {Mode: fs.ModeDir, ModTime: modTime},
+		},
+		expect: test.FSSpec{
+			"dir/file.txt": {Data: "data", ModTime: modTime},
+			"dir":          {Mode: fs.ModeDir, ModTime: modTime},
+		},
+		check: func(t test.Test, dir string) {
+			info, err := os.Stat(filepath.Join(dir, "dir"))
+			require.NoError(t, err)
+			assert.True(t, modTime.Equal(info.ModTime()))
+		},
+	},
+	"txtar-archive": {
+		spec: txtar.Parse([]byte("comment\n" +
+			"-- a.txt --\nalpha\n-- dir/b.txt --\nbeta\n")),
+		expect: map[string]string{
+			"a.txt":     "alpha\n",
+			"dir/b.txt": "beta\n",
+		},
+	},
+	"fs-map": {
+		spec: fstest.MapFS{
+			"a.txt":     {Data: []byte("alpha"), Mode: 0o640},
+			"dir/b.txt": {Data: []byte("beta"), ModTime: modTime},
+		},
+		expect: test.FSSpec{
+			"a.txt":     {Data: "alpha", Mode: 0o640},
+			"dir/b.txt": {Data: "beta", ModTime: modTime},
+		},
+		check: func(t test.Test, dir string) {
+			info, err := os.Stat(filepath.Join(dir, "dir"))
+			require.NoError(t, err)
+			assert.Equal(t, test.DefaultDirMode, info.Mode().Perm())
+		},
+	},
+
+	"mismatch-content": {
+		setup: test.Errorf("file system mismatch [%s]:\n%s", gomock.Any(),
+			"--- Want\n+++ Got\n@@ -1,3 +1,3 @@\n"+
+				" -- a.txt --\n-other\n+alpha\n \n"),
+		spec:   map[string]string{"a.txt": "alpha"},
+		expect: map[string]string{"a.txt": "other"},
+	},
+	"mismatch-missing": {
+		setup: test.Errorf("file system mismatch [%s]:\n%s", gomock.Any(),
+			"--- Want\n+++ Got\n@@ -1,5 +1,3 @@\n"+
+				" -- a.txt --\n alpha\n--- b.txt --\n-beta\n \n"),
+		spec: map[string]string{"a.txt": "alpha"},
+		expect: map[string]string{
+			"a.txt": "alpha",
+			"b.txt": "beta",
+		},
+	},
+	"mismatch-mode": {
+		setup: test.Errorf("file system mismatch [%s]:\n%s", gomock.Any(),
+			"--- Want\n+++ Got\n@@ -1,3 +1,3 @@\n"+
+				"--- a.txt [-rwx------] --\n+-- a.txt [-rw-r--r--] --\n"+
+				" alpha\n \n"),
+		spec:   map[string]string{"a.txt": "alpha"},
+		expect: test.FSSpec{"a.txt": {Data: "alpha", Mode: 0o700}},
+	},
+	"mismatch-symlink": {
+		setup: test.Errorf("file system mismatch [%s]:\n%s", gomock.Any(),
+			"--- Want\n+++ Got\n@@ -1,4 +1,4 @@\n"+
+				" -- a.txt --\n alpha\n--- link -> b.txt --\n"+
+				"+-- link -> a.txt --\n \n"),
+		spec: test.FSSpec{
+			"a.txt": {Data: "alpha"},
+			"link":  {Link: "a.txt"},
+		},
+		expect: test.FSSpec{
+			"a.txt": {Data: "alpha"},
+			"link":  {Link: "b.txt"},
+		},
+	},
+
+	"invalid-type": {
+		setup: test.Panic(test.NewErrInvalidType(1)),
+		spec:  1,
+	},
+	"invalid-absolute-path": {
+		setup: test.Panic(test.NewErrInvalidFSEntry(
+			"/etc/x", "absolute path")),
+		spec: map[string]string{"/etc/x": "x"},
+	},
+	"invalid-escaping-path": {
+		setup: test.Panic(test.NewErrInvalidFSEntry(
+			"../x", "escaping path")),
+		spec: map[string]string{"../x": "x"},
+	},
+	"invalid-escaping-nested-path": {
+		setup: test.Panic(test.NewErrInvalidFSEntry(
+			"dir/../../x", "escaping path")),
+		spec: test.FSSpec{"dir/../../x": {Data: "x"}},
+	},
+	"invalid-directory-data": {
+		setup: test.Panic(test.NewErrInvalidFSEntry(
+			"dir/", "directory with data")),
+		spec: map[string]string{"dir/": "data"},
+	},
+	"invalid-directory-spec-data": {
+		setup: test.Panic(test.NewErrInvalidFSEntry(
+			"dir", "directory with data")),
+		spec: test.FSSpec{"dir": {Data: "data", Mode: fs.ModeDir}},
+	},
+}
+
+func TestFS(t *testing.T) {
+	test.Map(t, fsTestCases).
+		Run(func(t test.Test, param FSParams) {
+			// Given
+			mock.NewMocks(t).Expect(param.setup)
+
+			// When
+			dir := test.FS(t, param.spec)
+
+			// Then
+			test.AssertFS(t, dir, param.expect)
+			if param.check != nil {
+				param.check(t, dir)
+			}
+		})
+}
@@ -9,9 +9,12 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/huandu/go-clone"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
 	"github.com/tkrop/go-testing/internal/reflect"
+	"github.com/tkrop/go-testing/mock"
 )
 
 // Ptr is a convenience function to obtain the pointer to the given value.
@@ -51,6 +54,10 @@ func First[T any](arg T, _ ...any) T { return arg }
 // test failure if no panic occurred or the panic response does not match the
 // expected value.
 func Recover(t Test, expect any) {
+	if ctx, ok := t.(*Context); ok {
+		ctx.recovered.Store(true)
+	}
+
 	// revive:disable-next-line:defer // caller is expected to use defer.
 	if actual := recover(); actual != nil {
 		assert.Equal(t, expect, actual)
@@ -59,6 +66,31 @@ func Recover(t Test, expect any) {
 	}
 }
 
+// isNil returns whether the given value is nil or a typed nil value, e.g. a
+// nil pointer stored in an interface.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch rvalue := reflect.ValueOf(value); rvalue.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface,
+		reflect.Map, reflect.Pointer, reflect.Slice:
+		return rvalue.IsNil()
+	default:
+		return false
+	}
+}
+
+// isStruct returns whether the given value is a struct or a pointer to a
+// struct.
+func isStruct(value any) bool {
+	rtype := reflect.TypeOf(value)
+	if rtype != nil && rtype.Kind() == reflect.Pointer {
+		rtype = rtype.Elem()
+	}
+	return rtype != nil && rtype.Kind() == reflect.Struct
+}
+
 // TODO: consider following convenience methods:
 //
 // // Check is a convenience method that returns the second argument and swallows
@@ -178,6 +210,30 @@ type DeepCopyParams struct {
 	// Value is a template value used to generate random non-zero test values
 	// for testing the `DeepCopy*` functions.
 	Value any
+	// Seed is the random seed used to mutate the copied value. Default is 1.
+	Seed int64
+	// Size is the slice and map size limit used to mutate the copied value.
+	// Default is 1.
+	Size int
+	// Length is the string length limit used to mutate the copied value.
+	// Default is 10.
+	Length int
+}
+
+// random creates the random generator to mutate the copied value using the
+// seed and limits of the parameters falling back to the defaults.
+func (p DeepCopyParams) random() reflect.Random {
+	seed, size, length := p.Seed, p.Size, p.Length
+	if seed == 0 {
+		seed = 1
+	}
+	if size <= 0 {
+		size = 1
+	}
+	if length <= 0 {
+		length = 10
+	}
+	return reflect.NewRandom(seed, size, length)
 }
 
 // typeToTestName converts a reflect.Type into a human readable test case name.
@@ -189,6 +245,12 @@ func typeToTestName(typ reflect.Type) string {
 	if raw == "" {
 		raw = typ.String()
 	}
+	return toTestName(raw)
+}
+
+// toTestName converts a CamelCase identifier into a human readable test case
+// name, i.e. a hyphen-separated lower-case string with a trailing separator.
+func toTestName(raw string) string {
 	runes := []rune(raw)
 
 	last := byte('-')
@@ -233,10 +295,11 @@ func DeepCopyTestCases(
 		nilPtr := reflect.Zero(ptrType).Interface()
 
 		cases[name+"nil"] = DeepCopyParams{
-			Value: nilPtr,
+			Value: nilPtr, Seed: seed, Size: size, Length: length,
 		}
 		cases[name+"value"] = DeepCopyParams{
 			Value: random.Random(nilPtr),
+			Seed:  seed, Size: size, Length: length,
 		}
 	}
 
@@ -248,10 +311,15 @@ func DeepCopyTestCases(
 // system.
 //
 // The test function verifies that the copied value is equal to the original
-// value but not the same reference. For simplicity, the function only requires
-// a template to generate random non-zero test values that ensure covering all
-// code paths. If this fails, you can vary the random seed, as well as the
-// limits on the slice and map sizes (`size`) and string lengths (`len`).
+// value but not the same reference. In addition, it mutates all values that
+// are reachable from the copied value and verifies that the original value is
+// unchanged, reporting the field path of each shared reference. Opaque types
+// of the standard library, e.g. `sync.Mutex`, are not mutated, since they may
+// be shared intentionally. The mutation uses the random seed and limits of the
+// parameters. For simplicity, the function only requires a template to
+// generate random non-zero test values that ensure covering all code paths. If
+// this fails, you can vary the random seed, as well as the limits on the slice
+// and map sizes (`size`) and string lengths (`len`).
 //
 // The following code shows a quick example of how to use this function in a
 // tests:
@@ -299,7 +367,27 @@ func DeepCopy(t Test, p DeepCopyParams) {
 		// Only check not Same for two pointer types.
 		assert.NotSame(t, value, result)
 	}
-	assert.Equal(t, value, result)
+	if assert.Equal(t, value, result) {
+		independent(t, p.random(), value, result)
+	}
+}
+
+// independent checks that the copied value does not share any references
+// with the original value. The check mutates all values reachable from the
+// copied value and reports each changed value of the original value with its
+// field path.
+func independent(t Test, random reflect.Random, value, result any) {
+	t.Helper()
+
+	snapshot := clone.Slowly(value)
+	copied := reflect.New(reflect.TypeOf(result))
+	copied.Elem().Set(reflect.ValueOf(result))
+	random.Mutate(copied.Interface())
+
+	for _, path := range mock.NewDiffConfig().Paths(snapshot, value) {
+		t.Errorf("deep copy shares reference [%s]: %s",
+			reflect.TypeOf(value), path)
+	}
 }
 
 // deepCopy performs a deep copy of the given value using the either the
@@ -7,10 +7,13 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
 	"github.com/tkrop/go-testing/mock"
 	"github.com/tkrop/go-testing/test"
 )
@@ -474,6 +477,32 @@ func main() {
 	os.Exit(test.First(strconv.Atoi(os.Getenv("exit"))))
 }
 
+// lazyCtx is a context with timeout that starts the timeout on first usage,
+// i.e. when the test process is started, instead of on test case creation.
+type lazyCtx struct {
+	once    sync.Once
+	timeout time.Duration
+	ctx     context.Context //nolint:containedctx // lazy context.
+}
+
+// newLazyCtx creates a new context with the given timeout that starts the
+// timeout on first usage.
+func newLazyCtx(timeout time.Duration) *lazyCtx {
+	return &lazyCtx{timeout: timeout}
+}
+
+func (c *lazyCtx) get() context.Context {
+	c.once.Do(func() {
+		c.ctx = test.First(context.WithTimeout(context.Background(), c.timeout))
+	})
+	return c.ctx
+}
+
+func (c *lazyCtx) Deadline() (time.Time, bool) { return c.get().Deadline() }
+func (c *lazyCtx) Done() <-chan struct{}       { return c.get().Done() }
+func (c *lazyCtx) Err() error                  { return c.get().Err() }
+func (c *lazyCtx) Value(key any) any           { return c.get().Value(key) }
+
 var mainTestCases = map[string]test.MainParams{
 	"panic": {
 		Env:      []string{"panic=true"},
@@ -502,9 +531,8 @@ var mainTestCases = map[string]test.MainParams{
 		ExitCode: -1,
 	},
 	"interrupt": {
-		Args: []string{"interrupt", "1s"},
-		Ctx: test.First(context.WithTimeout(context.Background(),
-			500*time.Millisecond)),
+		Args:     []string{"interrupt", "1s"},
+		Ctx:      newLazyCtx(500 * time.Millisecond),
 		ExitCode: -1,
 	},
 }
@@ -530,8 +558,33 @@ type (
 	mapDeepCopyObject      map[string]structDeepCopyObject
 	mapPtrDeepCopy         map[string]*structDeepCopy
 	mapPtrDeepCopyObject   map[string]*structDeepCopyObject
+	structShallowCopy      struct {
+		Values []int
+		Ptr    *structDeepCopy
+		items  map[string]int
+		ptr    *structDeepCopy
+	}
+	structShallowCopyPtr struct{ ptr *structDeepCopy }
 )
 
+func (d *structShallowCopy) DeepCopy() *structShallowCopy {
+	if d == nil {
+		return nil
+	}
+
+	copied := *d
+	return &copied
+}
+
+func (d *structShallowCopyPtr) DeepCopy() *structShallowCopyPtr {
+	if d == nil {
+		return nil
+	}
+
+	copied := *d
+	return &copied
+}
+
 func (d *structDeepCopy) DeepCopy() *structDeepCopy {
 	if d == nil {
 		return nil
@@ -835,11 +888,18 @@ var deepCopyTestCasesTestCases = map[string]DeepCopyCasesParams{
 func TestDeepCopyTestCases(t *testing.T) {
 	test.Map(t, deepCopyTestCasesTestCases).
 		Run(func(t test.Test, param DeepCopyCasesParams) {
+			// Given
+			expect := map[string]test.DeepCopyParams{}
+			for name, params := range param.expect {
+				params.Seed, params.Size, params.Length = 42, 3, 10
+				expect[name] = params
+			}
+
 			// When
 			cases := test.DeepCopyTestCases(42, 3, 10, param.args...)
 
 			// Then
-			assert.Equal(t, param.expect, cases)
+			assert.Equal(t, expect, cases)
 		})
 }
 
@@ -1075,6 +1135,40 @@ var deepCopyTestCases = map[string]DeepCopyParams{
 			},
 		},
 	},
+
+	// Shallow copies sharing references.
+	"struct-shallow-copy-value": {
+		DeepCopyParams: test.DeepCopyParams{
+			Value: &structShallowCopy{
+				Values: []int{1, 2},
+				Ptr:    &structDeepCopy{Value: 3},
+				items:  map[string]int{"key": 4},
+				ptr:    &structDeepCopy{Value: 5},
+			},
+		},
+		setup: mock.Chain(
+			sharedReference(&structShallowCopy{}, ".Values[0]"),
+			sharedReference(&structShallowCopy{}, ".Values[1]"),
+			sharedReference(&structShallowCopy{}, ".Ptr.Value"),
+			sharedReference(&structShallowCopy{}, `.items["key"]`),
+			sharedReference(&structShallowCopy{}, ".ptr.Value"),
+		),
+	},
+	"struct-shallow-copy-unexported-ptr": {
+		DeepCopyParams: test.DeepCopyParams{
+			Value: &structShallowCopyPtr{ptr: &structDeepCopy{Value: 6}},
+		},
+		setup: sharedReference(&structShallowCopyPtr{}, ".ptr.Value"),
+	},
+}
+
+// sharedReference creates the expected error for a reference shared by a
+// shallow copy at the given field path ignoring the mutated random value.
+func sharedReference(value any, path string) mock.SetupFunc {
+	return test.Errorf("deep copy shares reference [%s]: %s",
+		reflect.TypeOf(value), gomock.Cond(func(msg string) bool {
+			return strings.HasPrefix(msg, path+": want ")
+		}))
 }
 
 func TestDeepCopy(t *testing.T) {
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPollTick is the default interval between two checks of the
+	// condition of `Eventually` and `Consistently`.
+	DefaultPollTick = 10 * time.Millisecond
+	// DefaultPollWait is the default maximum duration of polling the condition
+	// of `Eventually`, if the test has no deadline.
+	DefaultPollWait = time.Second
+	// DefaultConsistentlyWait is the default duration of polling the condition
+	// of `Consistently`, if the test has no deadline, that is kept short to not
+	// slow down successful tests.
+	DefaultConsistentlyWait = 100 * time.Millisecond
+)
+
+// PollFunc defines the common signature of functions to configure the polling
+// of `Eventually` and `Consistently`.
+type PollFunc func(*poll)
+
+// poll is the configuration of the polling of `Eventually` and
+// `Consistently`.
+type poll struct {
+	// The maximum duration of polling the condition.
+	wait time.Duration
+	// The interval between two checks of the condition.
+	tick time.Duration
+}
+
+// Wait sets up the maximum duration of polling the condition. By default, the
+// condition is polled until the deadline of the test, that is reduced by the
+// `StopEarly` duration of the isolated test context. A wait duration exceeding
+// the deadline is ignored.
+func Wait(wait time.Duration) PollFunc {
+	return func(p *poll) {
+		p.wait = wait
+	}
+}
+
+// Tick sets up the interval between two checks of the condition. Default is
+// `DefaultPollTick`.
+func Tick(tick time.Duration) PollFunc {
+	return func(p *poll) {
+		p.tick = tick
+	}
+}
+
+// newPoll creates a new polling configuration applying the given functions.
+func newPoll(fncalls ...PollFunc) *poll {
+	p := &poll{tick: DefaultPollTick}
+	for _, fncall := range fncalls {
+		fncall(p)
+	}
+	return p
+}
+
+// end returns the time to end polling. The polling ends one tick ahead of the
+// test deadline to leave time for reporting the failures before the test is
+// stopped by the deadline. If the test has no deadline, the polling ends after
+// the given fallback duration.
+func (p *poll) end(t Test, start time.Time, fallback time.Duration) time.Time {
+	end := start.Add(fallback)
+	if deadline, ok := t.Deadline(); ok {
+		end = deadline.Add(-p.tick)
+	}
+	if p.wait > 0 && start.Add(p.wait).Before(end) {
+		end = start.Add(p.wait)
+	}
+	return end
+}
+
+// Eventually polls the given condition until it succeeds, i.e. until the
+// condition function finishes without failure. Each check of the condition is
+// executed against an isolated test context recording the failures, that is
+// aborted on the first fatal failure. If the condition is not succeeding
+// before the deadline of the test, the failures of the last check are reported
+// to the given test, e.g. the last observed value with a diff. The function
+// returns whether the condition has succeeded.
+//
+// The following example shows how to wait for the result of an asynchronous
+// operation:
+//
+// ```go
+//
+//	test.Eventually(t, func(t test.Test) {
+//		assert.Equal(t, "done", service.State())
+//	}, test.Tick(time.Millisecond))
+//
+// ```
+func Eventually(t Test, cond Func, fncalls ...PollFunc) bool {
+	t.Helper()
+
+	p, start := newPoll(fncalls...), time.Now()
+	end := p.end(t, start, DefaultPollWait)
+	for attempt := 1; ; attempt++ {
+		failures := softRun(t, cond, false)
+		if len(failures) == 0 {
+			return true
+		} else if time.Now().Add(p.tick).After(end) {
+			t.Errorf("eventually failed after %v [attempts: %d]:%s",
+				time.Since(start).Round(time.Millisecond), attempt,
+				strings.Join(failures, ""))
+			return false
+		}
+		time.Sleep(p.tick)
+	}
+}
+
+// Consistently polls the given condition until the deadline of the test to
+// ensure that it is succeeding all the time, i.e. the condition function
+// finishes without failure. Each check of the condition is executed against an
+// isolated test context recording the failures, that is aborted on the first
+// fatal failure. If a check of the condition fails, the polling is stopped and
+// the failures are reported to the given test, e.g. the observed value with a
+// diff. The function returns whether the condition has succeeded all the time.
+// If the test has no deadline, the condition is polled for
+// `DefaultConsistentlyWait`.
+//
+// *Note:* since the condition is polled until the deadline of the test by
+// default, the polling duration should be limited using a test timeout or
+// `Wait`.
+func Consistently(t Test, cond Func, fncalls ...PollFunc) bool {
+	t.Helper()
+
+	p, start := newPoll(fncalls...), time.Now()
+	end := p.end(t, start, DefaultConsistentlyWait)
+	for attempt := 1; ; attempt++ {
+		if failures := softRun(t, cond, false); len(failures) != 0 {
+			t.Errorf("consistently failed after %v [attempts: %d]:%s",
+				time.Since(start).Round(time.Millisecond), attempt,
+				strings.Join(failures, ""))
+			return false
+		} else if time.Now().Add(p.tick).After(end) {
+			return true
+		}
+		time.Sleep(p.tick)
+	}
+}
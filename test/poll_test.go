@@ -0,0 +1,173 @@
+package test_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tkrop/go-testing/test"
+)
+
+type PollParams struct {
+	poll           func(test.Test, test.Func, ...test.PollFunc) bool
+	cond           func(t test.Test, attempt int)
+	opts           []test.PollFunc
+	deadline       time.Duration
+	minDuration    time.Duration
+	maxDuration    time.Duration
+	expect         *regexp.Regexp
+	expectResult   bool
+	expectAttempts int
+}
+
+var pollTestCases = map[string]PollParams{
+	"eventually-immediately": {
+		poll:           test.Eventually,
+		cond:           func(test.Test, int) {},
+		expectResult:   true,
+		expectAttempts: 1,
+	},
+	"eventually-after-attempts": {
+		poll: test.Eventually,
+		cond: func(t test.Test, attempt int) {
+			assert.Equal(t, 3, attempt)
+		},
+		opts:           []test.PollFunc{test.Tick(time.Millisecond)},
+		expectResult:   true,
+		expectAttempts: 3,
+	},
+	"eventually-wait-failure": {
+		poll: test.Eventually,
+		cond: func(t test.Test, attempt int) {
+			assert.Equal(t, 0, attempt)
+		},
+		opts: []test.PollFunc{
+			test.Wait(20 * time.Millisecond), test.Tick(5 * time.Millisecond),
+		},
+		expect: regexp.MustCompile(`(?s)^eventually failed after [0-9]+m?s ` +
+			`\[attempts: [0-9]+\]:\n\tpoll_test.go:[0-9]+: Errorf: ` +
+			`\s*Error Trace:.*expected: 0\n.*actual  : [0-9]+\n.*$`),
+		expectAttempts: -1,
+	},
+	"eventually-fatal-failure": {
+		poll: test.Eventually,
+		cond: func(t test.Test, attempt int) {
+			require.Equal(t, 3, attempt)
+			t.Errorf("not reached")
+		},
+		opts: []test.PollFunc{
+			test.Wait(time.Millisecond), test.Tick(time.Millisecond),
+		},
+		expect: regexp.MustCompile(`(?s)^eventually failed after [0-9]+m?s ` +
+			`\[attempts: 1\]:\n\tpoll_test.go:[0-9]+: Errorf: ` +
+			`\s*Error Trace:.*expected: 3\n.*actual  : 1\n.*$`),
+		expectAttempts: 1,
+	},
+	"eventually-deadline-failure": {
+		poll: test.Eventually,
+		cond: func(t test.Test, _ int) {
+			t.Errorf("failure")
+		},
+		deadline: 50 * time.Millisecond,
+		expect: regexp.MustCompile(`^eventually failed after [0-9]+m?s ` +
+			`\[attempts: [0-9]+\]:\n\tpoll_test.go:[0-9]+: Errorf: failure$`),
+		expectAttempts: -1,
+	},
+	"consistently-success": {
+		poll: test.Consistently,
+		cond: func(t test.Test, attempt int) {
+			assert.Positive(t, attempt)
+		},
+		opts: []test.PollFunc{
+			test.Wait(20 * time.Millisecond), test.Tick(5 * time.Millisecond),
+		},
+		expectResult:   true,
+		expectAttempts: -1,
+	},
+	"consistently-deadline-success": {
+		poll:           test.Consistently,
+		cond:           func(test.Test, int) {},
+		deadline:       50 * time.Millisecond,
+		minDuration:    30 * time.Millisecond,
+		expectResult:   true,
+		expectAttempts: -1,
+	},
+	"consistently-default-wait": {
+		poll:           test.Consistently,
+		cond:           func(test.Test, int) {},
+		minDuration:    test.DefaultConsistentlyWait / 2,
+		maxDuration:    test.DefaultPollWait / 2,
+		expectResult:   true,
+		expectAttempts: -1,
+	},
+	"consistently-failure": {
+		poll: test.Consistently,
+		cond: func(t test.Test, attempt int) {
+			assert.Less(t, attempt, 3)
+		},
+		opts: []test.PollFunc{test.Tick(time.Millisecond)},
+		expect: regexp.MustCompile(`(?s)^consistently failed after [0-9]+m?s ` +
+			`\[attempts: 3\]:\n\tpoll_test.go:[0-9]+: Errorf: ` +
+			`\s*Error Trace:.*"3" is not less than "3".*$`),
+		expectAttempts: 3,
+	},
+}
+
+// deadlineTest is a test with a fixed deadline.
+type deadlineTest struct {
+	test.Test
+	deadline time.Time
+}
+
+func (t *deadlineTest) Deadline() (time.Time, bool) {
+	return t.deadline, !t.deadline.IsZero()
+}
+
+func TestPoll(t *testing.T) {
+	test.Map(t, pollTestCases).
+		Run(func(t test.Test, param PollParams) {
+			// Given
+			recorder := test.NewFailureRecorder(t)
+			attempts, result := 0, false
+			cond := func(t test.Test) {
+				attempts++
+				param.cond(t, attempts)
+			}
+
+			start := time.Now()
+			parent := &deadlineTest{Test: recorder}
+			if param.deadline > 0 {
+				parent.deadline = start.Add(param.deadline)
+			}
+
+			// When
+			recorder.Run(func(test.Test) {
+				result = param.poll(parent, cond, param.opts...)
+			})
+
+			// Then
+			assert.Equal(t, param.expectResult, result)
+			if param.expectAttempts > 0 {
+				assert.Equal(t, param.expectAttempts, attempts)
+			} else {
+				assert.Greater(t, attempts, 1)
+			}
+			if param.deadline > 0 {
+				assert.Less(t, time.Since(start), param.deadline)
+			}
+			if param.minDuration > 0 {
+				assert.GreaterOrEqual(t, time.Since(start), param.minDuration)
+			}
+			if param.maxDuration > 0 {
+				assert.Less(t, time.Since(start), param.maxDuration)
+			}
+			if param.expect != nil {
+				recorder.AssertFailures(t, param.expect)
+			} else {
+				recorder.AssertFailures(t)
+			}
+		})
+}
@@ -0,0 +1,338 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	gosync "sync"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Report is a single log, failure, or panic report captured by a
+// [FailureRecorder].
+type Report struct {
+	// Method is the name of the reporting method, e.g. `Errorf`.
+	Method string
+	// Message is the formatted message of the report.
+	Message string
+	// Caller is the source code location of the reporting call in the format
+	// `file:line`.
+	Caller string
+}
+
+// IsLog returns whether the report is a log message.
+func (r Report) IsLog() bool {
+	return r.Method == "Log" || r.Method == "Logf"
+}
+
+// IsFatal returns whether the report is a fatal failure that immediately
+// aborts the test execution, i.e. a `Fatal`, `Fatalf`, `FailNow`, or `Panic`.
+func (r Report) IsFatal() bool {
+	switch r.Method {
+	case "Fatal", "Fatalf", "FailNow", "Panic":
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the report.
+func (r Report) String() string {
+	return r.Caller + ": " + r.Method + ": " + r.Message
+}
+
+// FailureRecorder is a simple in-memory test reporter that captures all
+// reported log messages, failures, and panics including their caller location.
+// It can be used as a lightweight alternative to the [Validator] to test custom
+// assertion helpers and framework extensions without a mock controller setup.
+type FailureRecorder struct {
+	// Embeds the parent test to delegate all non-reporting methods.
+	Test
+	mu      gosync.Mutex
+	reports []Report
+	soft    bool
+}
+
+// NewFailureRecorder creates a new in-memory test reporter delegating all
+// non-reporting methods to the given parent test.
+func NewFailureRecorder(t Test) *FailureRecorder {
+	return &FailureRecorder{Test: t}
+}
+
+// Run runs the given test function in a detached goroutine using the recorder
+// as test and waits for its completion. Panics as well as fatal failures only
+// abort the test function, but not the calling test.
+func (r *FailureRecorder) Run(call Func) *FailureRecorder {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if arg := recover(); arg != nil {
+				// Skip the runtime panic frame to locate the panic.
+				r.record(2, "Panic", fmt.Sprint(arg))
+			}
+		}()
+
+		call(r)
+	}()
+	<-done
+
+	return r
+}
+
+// Reports returns a copy of all captured reports in order of occurrence.
+func (r *FailureRecorder) Reports() []Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Report{}, r.reports...)
+}
+
+// Failed reports whether a failure, i.e. a report that is not a log message,
+// has been captured.
+func (r *FailureRecorder) Failed() bool {
+	for _, failure := range r.Reports() {
+		if !failure.IsLog() {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the string representation of all captured reports.
+func (r *FailureRecorder) String() string {
+	var builder strings.Builder
+	for _, failure := range r.Reports() {
+		builder.WriteString("\n\t" + failure.String())
+	}
+	return builder.String()
+}
+
+// Log captures a log message.
+func (r *FailureRecorder) Log(args ...any) {
+	r.record(1, "Log", strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+// Logf captures a formatted log message.
+func (r *FailureRecorder) Logf(format string, args ...any) {
+	r.record(1, "Logf", fmt.Sprintf(format, args...))
+}
+
+// Error captures a failure message.
+func (r *FailureRecorder) Error(args ...any) {
+	r.record(1, "Error", strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+// Errorf captures a formatted failure message.
+func (r *FailureRecorder) Errorf(format string, args ...any) {
+	r.record(1, "Errorf", fmt.Sprintf(format, args...))
+}
+
+// Fatal captures a fatal failure message and aborts the test execution.
+func (r *FailureRecorder) Fatal(args ...any) {
+	r.record(1, "Fatal", strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+	r.exit()
+}
+
+// Fatalf captures a formatted fatal failure message and aborts the test
+// execution.
+func (r *FailureRecorder) Fatalf(format string, args ...any) {
+	r.record(1, "Fatalf", fmt.Sprintf(format, args...))
+	r.exit()
+}
+
+// Fail captures a failure without message.
+func (r *FailureRecorder) Fail() {
+	r.record(1, "Fail", "")
+}
+
+// FailNow captures a failure without message and aborts the test execution.
+func (r *FailureRecorder) FailNow() {
+	r.record(1, "FailNow", "")
+	r.exit()
+}
+
+// Panic captures a panic.
+func (r *FailureRecorder) Panic(arg any) {
+	r.record(1, "Panic", fmt.Sprint(arg))
+}
+
+// AssertFailures asserts that the captured failures, i.e. all reports except
+// log messages, match the given expectations in order. An expectation can be
+// a string, an error, a [*regexp.Regexp], or a [gomock.Matcher] that is
+// matched against the failure message.
+func (r *FailureRecorder) AssertFailures(t Test, expects ...any) bool {
+	t.Helper()
+
+	failures := []Report{}
+	for _, failure := range r.Reports() {
+		if !failure.IsLog() {
+			failures = append(failures, failure)
+		}
+	}
+
+	if len(failures) != len(expects) {
+		t.Errorf("failures mismatch: want %d, got %d%s",
+			len(expects), len(failures), r)
+		return false
+	}
+
+	result := true
+	for index, expect := range expects {
+		if !matchFailure(expect, failures[index].Message) {
+			t.Errorf("failure mismatch [%d]: want %s, got %s",
+				index, failureString(expect), failures[index])
+			result = false
+		}
+	}
+	return result
+}
+
+// AssertOrder asserts that the captured reports, including log messages,
+// contain messages matching the given expectations in the given order. Other
+// reports may occur in between. The expectations are matched like in
+// [FailureRecorder.AssertFailures].
+func (r *FailureRecorder) AssertOrder(t Test, expects ...any) bool {
+	t.Helper()
+
+	failures, index := r.Reports(), 0
+	for _, expect := range expects {
+		for index < len(failures) &&
+			!matchFailure(expect, failures[index].Message) {
+			index++
+		}
+		if index == len(failures) {
+			t.Errorf("failure order mismatch: want %s in order%s",
+				failureString(expect), r)
+			return false
+		}
+		index++
+	}
+	return true
+}
+
+// AssertFatal asserts that exactly one fatal failure, i.e. a `Fatal`,
+// `Fatalf`, `FailNow`, or `Panic`, has been captured and that its message
+// matches the given expectation. The expectation is matched like in
+// [FailureRecorder.AssertFailures].
+func (r *FailureRecorder) AssertFatal(t Test, expect any) bool {
+	t.Helper()
+
+	fatals := []Report{}
+	for _, failure := range r.Reports() {
+		if failure.IsFatal() {
+			fatals = append(fatals, failure)
+		}
+	}
+
+	if len(fatals) != 1 {
+		t.Errorf("fatal mismatch: want exactly one, got %d%s", len(fatals), r)
+		return false
+	} else if !matchFailure(expect, fatals[0].Message) {
+		t.Errorf("fatal mismatch: want %s, got %s",
+			failureString(expect), fatals[0])
+		return false
+	}
+	return true
+}
+
+// exit aborts the test execution after a fatal failure, unless the recorder
+// is collecting soft failures.
+func (r *FailureRecorder) exit() {
+	if !r.soft {
+		runtime.Goexit()
+	}
+}
+
+// record captures the report with given method name and message including
+// the caller location of the reporting call. The location is determined by
+// skipping the given number of stack frames above the calling method as well
+// as all frames of the test framework and the supported assertion libraries.
+func (r *FailureRecorder) record(skip int, method, message string) {
+	caller := location(skip + 2)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reports = append(r.reports, Report{
+		Method: method, Message: message, Caller: caller,
+	})
+}
+
+// packageModule is the module path prefix of the test framework functions.
+var packageModule = strings.TrimSuffix(
+	reflect.TypeOf(FailureRecorder{}).PkgPath(), "test")
+
+// frameworks contains the package path prefixes of the assertion libraries
+// and mock frameworks that are skipped when locating the caller.
+var frameworks = []string{
+	"github.com/stretchr/testify/",
+	"go.uber.org/mock/",
+	"runtime.",
+}
+
+// location returns the source code location of the first stack frame above
+// the given number of stack frames to skip, that is neither part of the test
+// framework nor of an assertion library or mock framework, in the format
+// `file:line`.
+func location(skip int) string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip+1, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !more || !framework(frame) {
+			if frame.File == "" {
+				return "???:0"
+			}
+			return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+	}
+}
+
+// framework returns whether the given stack frame is part of the test
+// framework, i.e. not of a test file, or part of an assertion library or mock
+// framework.
+func framework(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, packageModule) {
+		return !strings.HasSuffix(frame.File, "_test.go")
+	}
+	for _, prefix := range frameworks {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFailure matches the given failure message against the given
+// expectation.
+func matchFailure(expect any, message string) bool {
+	switch expect := expect.(type) {
+	case gomock.Matcher:
+		return expect.Matches(message)
+	case *regexp.Regexp:
+		return expect.MatchString(message)
+	case error:
+		return expect.Error() == message
+	default:
+		return fmt.Sprint(expect) == message
+	}
+}
+
+// failureString returns the string representation of the given failure
+// expectation.
+func failureString(expect any) string {
+	switch expect := expect.(type) {
+	case gomock.Matcher:
+		return expect.String()
+	case *regexp.Regexp:
+		return "matching " + strconv.Quote(expect.String())
+	default:
+		return strconv.Quote(fmt.Sprint(expect))
+	}
+}
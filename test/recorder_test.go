@@ -0,0 +1,200 @@
+package test_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/tkrop/go-testing/test"
+)
+
+type RecorderParams struct {
+	call         test.Func
+	expect       []test.Report
+	expectFailed bool
+}
+
+var recorderTestCases = map[string]RecorderParams{
+	"no-reports": {
+		call:   func(test.Test) {},
+		expect: []test.Report{},
+	},
+	"log-reports": {
+		call: func(t test.Test) {
+			t.Log("log", "message")
+			t.Logf("%s message", "logf")
+		},
+		expect: []test.Report{
+			{Method: "Log", Message: "log message"},
+			{Method: "Logf", Message: "logf message"},
+		},
+	},
+	"error-reports": {
+		call: func(t test.Test) {
+			t.Error("error", "message")
+			t.Errorf("%s message", "errorf")
+			t.Fail()
+		},
+		expect: []test.Report{
+			{Method: "Error", Message: "error message"},
+			{Method: "Errorf", Message: "errorf message"},
+			{Method: "Fail"},
+		},
+		expectFailed: true,
+	},
+	"fatal-report": {
+		call: func(t test.Test) {
+			t.Fatal("fatal", "message")
+			t.Errorf("not reached")
+		},
+		expect: []test.Report{
+			{Method: "Fatal", Message: "fatal message"},
+		},
+		expectFailed: true,
+	},
+	"fatalf-report": {
+		call: func(t test.Test) {
+			t.Fatalf("%s message", "fatalf")
+			t.Errorf("not reached")
+		},
+		expect: []test.Report{
+			{Method: "Fatalf", Message: "fatalf message"},
+		},
+		expectFailed: true,
+	},
+	"failnow-report": {
+		call: func(t test.Test) {
+			t.FailNow()
+			t.Errorf("not reached")
+		},
+		expect: []test.Report{
+			{Method: "FailNow"},
+		},
+		expectFailed: true,
+	},
+	"panic-report": {
+		call: func(test.Test) {
+			panic("panic message")
+		},
+		expect: []test.Report{
+			{Method: "Panic", Message: "panic message"},
+		},
+		expectFailed: true,
+	},
+}
+
+func TestRecorder(t *testing.T) {
+	test.Map(t, recorderTestCases).
+		Run(func(t test.Test, param RecorderParams) {
+			// Given
+			recorder := test.NewFailureRecorder(t)
+
+			// When
+			reports := recorder.Run(param.call).Reports()
+
+			// Then
+			for index, report := range reports {
+				assert.True(t, strings.HasPrefix(report.Caller,
+					"recorder_test.go:"), report.Caller)
+				reports[index].Caller = ""
+			}
+			assert.Equal(t, param.expect, reports)
+			assert.Equal(t, param.expectFailed, recorder.Failed())
+		})
+}
+
+// recorderCall is a test function creating a sequence of reports for testing
+// the recorder assertions.
+func recorderCall(t test.Test) {
+	t.Log("log message")
+	t.Errorf("error %d", 1)
+	t.Errorf("error %d", 2)
+	t.Fatalf("fatal message")
+}
+
+type RecorderAssertParams struct {
+	assert func(t test.Test, recorder *test.FailureRecorder) bool
+	expect []string
+}
+
+var recorderAssertTestCases = map[string]RecorderAssertParams{
+	"failures-match": {
+		assert: func(t test.Test, recorder *test.FailureRecorder) bool {
+			return recorder.AssertFailures(t, "error 1",
+				regexp.MustCompile("^error [0-9]$"), gomock.Any())
+		},
+	},
+	"failures-count-mismatch": {
+		assert: func(t test.Test, recorder *test.FailureRecorder) bool {
+			return recorder.AssertFailures(t, "error 1")
+		},
+		expect: []string{"failures mismatch: want 1, got 3"},
+	},
+	"failures-message-mismatch": {
+		assert: func(t test.Test, recorder *test.FailureRecorder) bool {
+			return recorder.AssertFailures(t, "error 1",
+				"error 3", assert.AnError)
+		},
+		expect: []string{
+			`failure mismatch [1]: want "error 3", got recorder_test.go:`,
+			`failure mismatch [2]: want "` + assert.AnError.Error() +
+				`", got recorder_test.go:`,
+		},
+	},
+	"order-match": {
+		assert: func(t test.Test, recorder *test.FailureRecorder) bool {
+			return recorder.AssertOrder(t, "log message", "error 2",
+				"fatal message")
+		},
+	},
+	"order-mismatch": {
+		assert: func(t test.Test, recorder *test.FailureRecorder) bool {
+			return recorder.AssertOrder(t, "error 2", "error 1")
+		},
+		expect: []string{`failure order mismatch: want "error 1" in order`},
+	},
+	"fatal-match": {
+		assert: func(t test.Test, recorder *test.FailureRecorder) bool {
+			return recorder.AssertFatal(t, "fatal message")
+		},
+	},
+	"fatal-mismatch": {
+		assert: func(t test.Test, recorder *test.FailureRecorder) bool {
+			return recorder.AssertFatal(t,
+				regexp.MustCompile("^other"))
+		},
+		expect: []string{`fatal mismatch: want matching "^other", got ` +
+			`recorder_test.go:`},
+	},
+	"fatal-missing": {
+		assert: func(t test.Test, _ *test.FailureRecorder) bool {
+			return test.NewFailureRecorder(t).AssertFatal(t, "fatal message")
+		},
+		expect: []string{"fatal mismatch: want exactly one, got 0"},
+	},
+}
+
+func TestRecorderAssert(t *testing.T) {
+	test.Map(t, recorderAssertTestCases).
+		Run(func(t test.Test, param RecorderAssertParams) {
+			// Given
+			recorder := test.NewFailureRecorder(t).Run(recorderCall)
+			validator := test.NewFailureRecorder(t)
+
+			// When
+			result := param.assert(validator, recorder)
+
+			// Then
+			reports := validator.Reports()
+			assert.Equal(t, len(param.expect) == 0, result)
+			if assert.Len(t, reports, len(param.expect)) {
+				for index, expect := range param.expect {
+					assert.Equal(t, "Errorf", reports[index].Method)
+					assert.Contains(t, reports[index].Message, expect)
+				}
+			}
+		})
+}
@@ -58,10 +58,10 @@ type Recorder struct {
 func NewValidator(ctrl *gomock.Controller) *Validator {
 	validator := &Validator{ctrl: ctrl}
 	validator.recorder = &Recorder{validator: validator}
-	if t, ok := ctrl.T.(*Context); ok {
+	if t, ok := unwrap(ctrl.T).(*Context); ok {
 		// We need to install a second isolated test environment to break the
 		// reporter cycle on the failure issued by the mock controller.
-		ctrl.T = New(t.t, t.parallel).Expect(t.expect)
+		ctrl.T = t.isolate()
 		t.expect = false
 		t.Reporter(validator)
 	}
@@ -378,3 +378,12 @@ func (m *callMatcher) Matches(x any) bool {
 func (m *callMatcher) String() string {
 	return fmt.Sprintf("is equal to %v (%T)", m.x, m.x)
 }
+
+// unwrap returns the original test reporter of the given test reporter, if
+// it is wrapping another test reporter, e.g. to add details to failures.
+func unwrap(t gomock.TestHelper) gomock.TestHelper {
+	if reporter, ok := t.(interface{ Unwrap() gomock.TestHelper }); ok {
+		return reporter.Unwrap()
+	}
+	return t
+}
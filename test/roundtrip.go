@@ -0,0 +1,176 @@
+package test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+
+	"github.com/tkrop/go-testing/internal/reflect"
+	"github.com/tkrop/go-testing/mock"
+)
+
+// RoundTripParams provides test parameters for testing the marshal and
+// unmarshal methods of a type, i.e. `json.Marshaler`/`json.Unmarshaler`,
+// `encoding.TextMarshaler`/`encoding.TextUnmarshaler`, and
+// `encoding.BinaryMarshaler`/`encoding.BinaryUnmarshaler`.
+type RoundTripParams struct {
+	// Value is the pointer to the value used for testing the round trips.
+	Value any
+	// Gob enables an additional `encoding/gob` round trip of the value.
+	Gob bool
+}
+
+// RoundTripTestCases creates the test cases from a list of types to be tested
+// either given as value or as nil pointer. For each type a test case with a
+// non-nil random value is created. The `encoding/gob` round trip is enabled,
+// if the type implements the `gob.GobEncoder` and `gob.GobDecoder`
+// interfaces.
+func RoundTripTestCases(
+	seed int64, size, length int, args ...any,
+) map[string]RoundTripParams {
+	random := reflect.NewRandom(seed, size, length)
+	cases := make(map[string]RoundTripParams)
+	for _, arg := range args {
+		base := reflect.TypeOf(arg)
+		for base.Kind() == reflect.Ptr {
+			base = base.Elem()
+		}
+		name := typeToTestName(base)
+
+		ptrType := reflect.PointerTo(base)
+		nilPtr := reflect.Zero(ptrType).Interface()
+
+		cases[name+"value"] = RoundTripParams{
+			Value: random.Random(nilPtr),
+			Gob:   gobCodec.supports(ptrType),
+		}
+	}
+
+	return cases
+}
+
+// RoundTrip provides a test function that tests the marshal and unmarshal
+// methods of a type by marshaling the value and unmarshaling the result into
+// a new value of the same type for each supported encoding. The test function
+// verifies that the new value is equal to the original value and reports the
+// diff on mismatch. An encoding is supported, if the type implements both,
+// the marshal and the unmarshal method. The `encoding/gob` round trip can be
+// enabled explicitly for types without `GobEncode` and `GobDecode` methods.
+//
+// The following code shows a quick example of how to use this function in a
+// tests:
+//
+// ```go
+//
+//	var roundTripTestCases = RoundTripTestCases(42, 3, 10,
+//		   &MyStruct{}, (*MyEnum)(nil), ...)
+//
+//	func TestRoundTrip(t *testing.T) {
+//		test.Map(t, roundTripTestCases).Run(test.RoundTrip)
+//	}
+//
+// ```
+// *Note:* the test cases can also be generated inside the `TestRoundTrip`
+// function.
+func RoundTrip(t Test, p RoundTripParams) {
+	// Given
+	value := reflect.ValueOf(p.Value)
+	if !value.IsValid() || value.Kind() != reflect.Ptr || value.IsNil() {
+		t.Fatalf("no round trip value [%T]", p.Value)
+	}
+
+	codecs := 0
+	for _, codec := range roundTripCodecs {
+		if !codec.supports(value.Type()) && (codec != gobCodec || !p.Gob) {
+			continue
+		}
+		codecs++
+
+		// When
+		result := reflect.New(value.Type().Elem()).Interface()
+		data, err := codec.marshal(p.Value)
+		if err != nil {
+			t.Errorf("%s marshal failed [%T]: %v", codec.name, p.Value, err)
+			continue
+		} else if err := codec.unmarshal(data, result); err != nil {
+			t.Errorf("%s unmarshal failed [%T]: %v", codec.name, p.Value, err)
+			continue
+		}
+
+		// Then
+		config := mock.NewDiffConfig()
+		if !config.Match(p.Value, result) {
+			t.Errorf("%s round trip mismatch [%T]:\n%s", codec.name,
+				p.Value, config.Diff(p.Value, result))
+		}
+	}
+
+	if codecs == 0 {
+		t.Fatalf("no marshal methods [%T]", p.Value)
+	}
+}
+
+// roundTripCodec defines an encoding used for testing round trips of values
+// by the interfaces that need to be implemented by the type.
+type roundTripCodec struct {
+	name        string
+	marshaler   reflect.Type
+	unmarshaler reflect.Type
+	marshal     func(value any) ([]byte, error)
+	unmarshal   func(data []byte, value any) error
+}
+
+// supports returns whether the given pointer type implements the marshal and
+// unmarshal methods of the encoding.
+func (c *roundTripCodec) supports(ptrType reflect.Type) bool {
+	return ptrType.Implements(c.marshaler) &&
+		ptrType.Implements(c.unmarshaler)
+}
+
+// roundTripCodecs is the list of encodings used for testing round trips.
+var roundTripCodecs = []*roundTripCodec{{
+	name:        "json",
+	marshaler:   reflect.TypeOf((*json.Marshaler)(nil)).Elem(),
+	unmarshaler: reflect.TypeOf((*json.Unmarshaler)(nil)).Elem(),
+	marshal:     json.Marshal,
+	unmarshal:   json.Unmarshal,
+}, {
+	name:        "text",
+	marshaler:   reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem(),
+	unmarshaler: reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem(),
+	marshal: func(value any) ([]byte, error) {
+		return value.(encoding.TextMarshaler).MarshalText()
+	},
+	unmarshal: func(data []byte, value any) error {
+		return value.(encoding.TextUnmarshaler).UnmarshalText(data)
+	},
+}, {
+	name:        "binary",
+	marshaler:   reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem(),
+	unmarshaler: reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem(),
+	marshal: func(value any) ([]byte, error) {
+		return value.(encoding.BinaryMarshaler).MarshalBinary()
+	},
+	unmarshal: func(data []byte, value any) error {
+		return value.(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+	},
+}, gobCodec}
+
+// gobCodec is the `encoding/gob` encoding used for testing round trips. The
+// encoding is supported by most types, but must be enabled explicitly, if
+// the type does not implement the `gob.GobEncoder` and `gob.GobDecoder`
+// interfaces.
+var gobCodec = &roundTripCodec{
+	name:        "gob",
+	marshaler:   reflect.TypeOf((*gob.GobEncoder)(nil)).Elem(),
+	unmarshaler: reflect.TypeOf((*gob.GobDecoder)(nil)).Elem(),
+	marshal: func(value any) ([]byte, error) {
+		buffer := &bytes.Buffer{}
+		err := gob.NewEncoder(buffer).Encode(value)
+		return buffer.Bytes(), err
+	},
+	unmarshal: func(data []byte, value any) error {
+		return gob.NewDecoder(bytes.NewReader(data)).Decode(value)
+	},
+}
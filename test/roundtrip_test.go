@@ -0,0 +1,244 @@
+package test_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+type (
+	jsonRoundTrip   struct{ Name string }
+	textRoundTrip   struct{ Value int }
+	binaryRoundTrip struct{ Value uint64 }
+	gobRoundTrip    struct{ Value int }
+	plainRoundTrip  struct{ Value int }
+	lossyRoundTrip  struct {
+		Value int
+		Name  string
+	}
+	failMarshal   struct{ Value int }
+	failUnmarshal struct{ Value int }
+)
+
+// errRoundTrip is the error returned by failing marshal methods.
+var errRoundTrip = errors.New("round trip failure")
+
+func (r *jsonRoundTrip) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{r.Name})
+}
+
+func (r *jsonRoundTrip) UnmarshalJSON(data []byte) error {
+	names := []string{}
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	r.Name = names[0]
+	return nil
+}
+
+func (r *textRoundTrip) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(r.Value)), nil
+}
+
+func (r *textRoundTrip) UnmarshalText(data []byte) (err error) {
+	r.Value, err = strconv.Atoi(string(data))
+	return err
+}
+
+func (r *binaryRoundTrip) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(nil, r.Value), nil
+}
+
+func (r *binaryRoundTrip) UnmarshalBinary(data []byte) error {
+	r.Value = binary.BigEndian.Uint64(data)
+	return nil
+}
+
+func (r *gobRoundTrip) GobEncode() ([]byte, error) {
+	return []byte(strconv.Itoa(r.Value)), nil
+}
+
+func (r *gobRoundTrip) GobDecode(data []byte) (err error) {
+	r.Value, err = strconv.Atoi(string(data))
+	return err
+}
+
+func (r *lossyRoundTrip) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(r.Value)), nil
+}
+
+func (r *lossyRoundTrip) UnmarshalText(data []byte) (err error) {
+	r.Value, err = strconv.Atoi(string(data))
+	return err
+}
+
+func (*failMarshal) MarshalBinary() ([]byte, error) {
+	return nil, errRoundTrip
+}
+
+func (*failMarshal) UnmarshalBinary([]byte) error {
+	return nil
+}
+
+func (r *failUnmarshal) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(r.Value)), nil
+}
+
+func (*failUnmarshal) UnmarshalText([]byte) error {
+	return errRoundTrip
+}
+
+type RoundTripCasesParams struct {
+	args   []any
+	expect map[string]test.RoundTripParams
+}
+
+var roundTripTestCasesTestCases = map[string]RoundTripCasesParams{
+	"json-round-trip": {
+		args: []any{&jsonRoundTrip{}},
+		expect: map[string]test.RoundTripParams{
+			"json-round-trip-value": {
+				Value: &jsonRoundTrip{Name: "9645z7iuh"},
+			},
+		},
+	},
+	"gob-round-trip": {
+		args: []any{(*gobRoundTrip)(nil)},
+		expect: map[string]test.RoundTripParams{
+			"gob-round-trip-value": {
+				Value: &gobRoundTrip{Value: 1202},
+				Gob:   true,
+			},
+		},
+	},
+	"plain-round-trip": {
+		args: []any{plainRoundTrip{}},
+		expect: map[string]test.RoundTripParams{
+			"plain-round-trip-value": {
+				Value: &plainRoundTrip{Value: 1202},
+			},
+		},
+	},
+}
+
+func TestRoundTripTestCases(t *testing.T) {
+	test.Map(t, roundTripTestCasesTestCases).
+		Run(func(t test.Test, param RoundTripCasesParams) {
+			// When
+			cases := test.RoundTripTestCases(42, 3, 10, param.args...)
+
+			// Then
+			assert.Equal(t, param.expect, cases)
+		})
+}
+
+type RoundTripParams struct {
+	test.RoundTripParams
+	setup mock.SetupFunc
+}
+
+var roundTripTestCases = map[string]RoundTripParams{
+	"invalid-nil": {
+		RoundTripParams: test.RoundTripParams{
+			Value: nil,
+		},
+		setup: test.Fatalf("no round trip value [%T]", nil),
+	},
+	"nil-pointer": {
+		RoundTripParams: test.RoundTripParams{
+			Value: (*jsonRoundTrip)(nil),
+		},
+		setup: test.Fatalf("no round trip value [%T]",
+			(*jsonRoundTrip)(nil)),
+	},
+	"no-pointer": {
+		RoundTripParams: test.RoundTripParams{
+			Value: jsonRoundTrip{Name: "name"},
+		},
+		setup: test.Fatalf("no round trip value [%T]",
+			jsonRoundTrip{Name: "name"}),
+	},
+	"no-marshal-methods": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &plainRoundTrip{Value: 1},
+		},
+		setup: test.Fatalf("no marshal methods [%T]",
+			&plainRoundTrip{Value: 1}),
+	},
+
+	"json-round-trip": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &jsonRoundTrip{Name: "name"},
+		},
+	},
+	"text-round-trip": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &textRoundTrip{Value: 1},
+		},
+	},
+	"binary-round-trip": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &binaryRoundTrip{Value: 1},
+		},
+	},
+	"gob-round-trip": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &gobRoundTrip{Value: 1},
+			Gob:   true,
+		},
+	},
+	"gob-plain-round-trip": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &plainRoundTrip{Value: 1},
+			Gob:   true,
+		},
+	},
+
+	"lossy-round-trip": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &lossyRoundTrip{Value: 1, Name: "name"},
+		},
+		setup: test.Errorf("%s round trip mismatch [%T]:\n%s", "text",
+			&lossyRoundTrip{Value: 1, Name: "name"}, ""+
+				"--- Want\n"+
+				"+++ Got\n"+
+				"@@ -1,5 +1,5 @@\n"+
+				" (*test_test.lossyRoundTrip)({\n"+
+				"   Value: (int) 1,\n"+
+				"-  Name: (string) (len=4) \"name\"\n"+
+				"+  Name: (string) \"\"\n"+
+				" })\n \n"),
+	},
+	"fail-marshal": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &failMarshal{Value: 1},
+		},
+		setup: test.Errorf("%s marshal failed [%T]: %v", "binary",
+			&failMarshal{Value: 1}, errRoundTrip),
+	},
+	"fail-unmarshal": {
+		RoundTripParams: test.RoundTripParams{
+			Value: &failUnmarshal{Value: 1},
+		},
+		setup: test.Errorf("%s unmarshal failed [%T]: %v", "text",
+			&failUnmarshal{Value: 1}, errRoundTrip),
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	test.Map(t, roundTripTestCases).
+		Run(func(t test.Test, param RoundTripParams) {
+			// Given
+			mock.NewMocks(t).Expect(param.setup)
+
+			// When
+			test.RoundTrip(t, param.RoundTripParams)
+		})
+}
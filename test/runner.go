@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	gosync "sync"
 	"testing"
 	"time"
 
+	"go.uber.org/mock/gomock"
+
 	"github.com/tkrop/go-testing/internal/maps"
 	"github.com/tkrop/go-testing/internal/slices"
 	"github.com/tkrop/go-testing/internal/sync"
@@ -108,6 +112,17 @@ type Factory[P any] interface {
 	// global test deadline. This is useful to ensure that resources can be
 	// cleaned up before the global deadline is exceeded.
 	StopEarly(time time.Duration) Factory[P]
+	// Budget sets up a default performance budget for the test cases executed
+	// by the test runner. A test case fails, if the test function takes longer
+	// than the given duration or allocates more than the given number of
+	// objects. The budget can be overwritten per test case using the fields
+	// `maxDuration` and `maxAllocs`. A zero or negative value disables the
+	// respective check.
+	Budget(duration time.Duration, allocs int) Factory[P]
+	// Slowest sets up the number of slowest test cases that are logged in a
+	// summary after all test cases have finished. A zero or negative number
+	// disables the summary.
+	Slowest(num int) Factory[P]
 	// Run runs all test parameter sets in parallel. If the test parameter sets
 	// are provided as a map, the test case name is used as the test name. If
 	// the test parameter sets are provided as a slice, the test case name is
@@ -122,6 +137,15 @@ type Factory[P any] interface {
 	// sets are provided as a single parameter set, the test case name is used
 	// as the test name. The test case name is normalized before being used.
 	RunSeq(call ParamFunc[P]) Factory[P]
+	// RunCheck runs all test parameter sets in parallel like `Run`, but the
+	// test function is returning a result and an error that are automatically
+	// checked against the expectations of the parameter set. The error is
+	// checked against the `expectError` field using `errors.Is`, `errors.As`,
+	// or a matcher, while the result is checked against an `expect` or
+	// `expectResult` field, or else each `expect`-prefixed field is checked
+	// against the result field with the same name. On mismatch a detailed
+	// diff is reported.
+	RunCheck(call CheckFunc[P]) Factory[P]
 	// Cleanup register a function to be called to cleanup after all tests have
 	// finished to remove the shared resources.
 	Cleanup(call CleanupFunc)
@@ -142,6 +166,16 @@ type factory[P any] struct {
 	timeout time.Duration
 	// A time reserved for cleaning up resources before reaching the deadline.
 	early time.Duration
+	// A default maximum duration of a test case.
+	duration time.Duration
+	// A default maximum number of allocations of a test case.
+	allocs int
+	// The number of slowest test cases to log in a summary.
+	slowest int
+	// A mutex to protect the recorded elapsed times.
+	mu gosync.Mutex
+	// The elapsed times of the test cases by test name.
+	elapsed map[string]time.Duration
 }
 
 // Any creates a new parallel test runner with given parameter set(s). The set
@@ -212,6 +246,29 @@ func (r *factory[P]) StopEarly(early time.Duration) Factory[P] {
 	return r
 }
 
+// Budget can be used to set up a default performance budget for the test
+// cases executed by the test runner. A test case fails, if the test function
+// takes longer than the given duration or allocates more than the given number
+// of objects. The budget can be overwritten per test case using the fields
+// `maxDuration` and `maxAllocs`. A zero or negative value disables the
+// respective check.
+func (r *factory[P]) Budget(duration time.Duration, allocs int) Factory[P] {
+	r.duration, r.allocs = duration, allocs
+	return r
+}
+
+// Slowest can be used to set up the number of slowest test cases that are
+// logged in a summary after all test cases have finished. A zero or negative
+// number disables the summary.
+func (r *factory[P]) Slowest(num int) Factory[P] {
+	if r.elapsed == nil && num > 0 {
+		r.elapsed = map[string]time.Duration{}
+		r.t.Cleanup(r.summary)
+	}
+	r.slowest = num
+	return r
+}
+
 // Run runs the test parameter sets (by default) parallel.
 func (r *factory[P]) Run(call ParamFunc[P]) Factory[P] {
 	return r.run(call, Parallel)
@@ -222,6 +279,17 @@ func (r *factory[P]) RunSeq(call ParamFunc[P]) Factory[P] {
 	return r.run(call, !Parallel)
 }
 
+// RunCheck runs the test parameter sets (by default) parallel checking the
+// returned result and error against the expectations of the parameter set.
+func (r *factory[P]) RunCheck(call CheckFunc[P]) Factory[P] {
+	return r.run(func(t Test, param P) {
+		t.Helper()
+
+		result, err := call(t, param)
+		check(t, param, result, err)
+	}, Parallel)
+}
+
 // Cleanup register a function to be called for cleanup after all tests have
 // been finished - successful and failing.
 func (r *factory[P]) Cleanup(call CleanupFunc) {
@@ -309,15 +377,134 @@ func (r *factory[P]) wrap(
 	return func(t *testing.T) {
 		t.Helper()
 
-		New(t, parallel).
+		ctx := New(t, parallel).
 			Expect(reflect.Find(param, Success, "expect", "*")).
 			Timeout(reflect.Find(param, r.timeout, "timeout")).
 			StopEarly(reflect.Find(param, r.early, "early")).
-			Run(func(t Test) {
-				t.Helper()
+			Budget(findField(param, r.duration, "maxDuration"),
+				findField(param, r.allocs, "maxAllocs"))
+		ctx.Run(func(t Test) {
+			t.Helper()
+
+			defer r.wg.Done()
+			r.call(t, param, call)
+		})
+		r.record(t.Name(), ctx.Elapsed())
+	}
+}
+
+// findField returns the value of the first struct field of the given parameter
+// set matching one of the given names and the type of the given default value.
+// In contrast to [reflect.Find], a parameter set that is not a struct is never
+// used as value, but the default value is returned.
+func findField[P, T any](param P, deflt T, names ...string) T {
+	if !isStruct(param) {
+		return deflt
+	}
+	return reflect.Find(param, deflt, names...)
+}
+
+// record records the elapsed time of the test case with the given name, if
+// a summary of the slowest test cases is requested.
+func (r *factory[P]) record(name string, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.elapsed != nil {
+		r.elapsed[name] = elapsed
+	}
+}
+
+// summary logs the summary of the slowest test cases after all test cases
+// have finished.
+func (r *factory[P]) summary() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.slowest <= 0 || len(r.elapsed) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(r.elapsed))
+	for name := range r.elapsed {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if r.elapsed[names[i]] != r.elapsed[names[j]] {
+			return r.elapsed[names[i]] > r.elapsed[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var builder strings.Builder
+	for _, name := range names[:min(r.slowest, len(names))] {
+		fmt.Fprintf(&builder, "\n\t%v\t%s", r.elapsed[name], name)
+	}
+	r.t.Logf("slowest test cases:%s", builder.String())
+}
+
+// call calls the test function with the given parameter set. If the parameter
+// set provides a non-nil `expectPanic` field, the test function is expected
+// to panic with a matching value. The panic value is matched by equality, by
+// [errors.Is], or by the [gomock.Matcher] provided as expectation. A test
+// function that returns without panic is reported as failure, unless the test
+// function has handled the panic itself using [Recover].
+func (*factory[P]) call(t Test, param P, call ParamFunc[P]) {
+	t.Helper()
+
+	expect := reflect.Find[P, any](param, nil, "expectPanic", "ExpectPanic")
+	if isNil(expect) {
+		call(t, param)
+		return
+	}
+
+	returned := false
+	defer func() {
+		t.Helper()
+
+		// Ignore test functions that have been stopped via `runtime.Goexit`.
+		if arg := recover(); arg != nil {
+			if !matchPanic(expect, arg) {
+				t.Errorf("panic mismatch: want %s, got %v",
+					panicString(expect), arg)
+			}
+		} else if returned && !recovered(t) {
+			t.Errorf("did not panic: want %s", panicString(expect))
+		}
+	}()
+
+	call(t, param)
+	returned = true
+}
+
+// recovered returns whether the test function has handled an expected panic
+// itself using [Recover].
+func recovered(t Test) bool {
+	ctx, ok := t.(*Context)
+	return ok && ctx.recovered.Load()
+}
+
+// matchPanic matches the given panic value against the given expectation by
+// either using the expectation as [gomock.Matcher], by comparing errors using
+// [errors.Is], or by comparing the values for equality allowing to compare
+// errors with their message.
+func matchPanic(expect, arg any) bool {
+	switch expect := expect.(type) {
+	case gomock.Matcher:
+		return expect.Matches(arg)
+	case error:
+		if err, ok := arg.(error); ok && errors.Is(err, expect) {
+			return true
+		}
+	}
+	return EqError(expect).Matches(arg)
+}
 
-				defer r.wg.Done()
-				call(t, param)
-			})
+// panicString returns the string representation of the given panic
+// expectation.
+func panicString(expect any) string {
+	if matcher, ok := expect.(gomock.Matcher); ok {
+		return matcher.String()
 	}
+	return fmt.Sprintf("%v", expect)
 }
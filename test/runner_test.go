@@ -1,12 +1,17 @@
 package test_test
 
 import (
+	"fmt"
 	"runtime"
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/tkrop/go-testing/mock"
 	"github.com/tkrop/go-testing/test"
 )
 
@@ -495,3 +500,172 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+type ExpectPanicParams struct {
+	setup       mock.SetupFunc
+	expectPanic any
+	panic       any
+	fatal       bool
+	recover     bool
+}
+
+var expectPanicTestCases = map[string]ExpectPanicParams{
+	"no-expect-no-panic": {},
+	"expect-string": {
+		expectPanic: "fail",
+		panic:       "fail",
+	},
+	"expect-error": {
+		expectPanic: assert.AnError,
+		panic:       assert.AnError,
+	},
+	"expect-wrapped-error": {
+		expectPanic: assert.AnError,
+		panic:       fmt.Errorf("wrapped: %w", assert.AnError),
+	},
+	"expect-error-message": {
+		expectPanic: assert.AnError.Error(),
+		panic:       assert.AnError,
+	},
+	"expect-matcher": {
+		expectPanic: gomock.Any(),
+		panic:       "fail",
+	},
+	"expect-recovered": {
+		expectPanic: "fail",
+		panic:       "fail",
+		recover:     true,
+	},
+	"expect-typed-nil": {
+		expectPanic: (*ExpectPanicParams)(nil),
+	},
+	"expect-goexit": {
+		setup:       test.Fatalf("fail"),
+		expectPanic: "fail",
+		fatal:       true,
+	},
+
+	"mismatch-string": {
+		setup: test.Errorf("panic mismatch: want %s, got %v",
+			"fail", "other"),
+		expectPanic: "fail",
+		panic:       "other",
+	},
+	"mismatch-error": {
+		setup: test.Errorf("panic mismatch: want %s, got %v",
+			assert.AnError.Error(), "other"),
+		expectPanic: assert.AnError,
+		panic:       "other",
+	},
+	"mismatch-matcher": {
+		setup: test.Errorf("panic mismatch: want %s, got %v",
+			"is equal to fail (string)", "other"),
+		expectPanic: gomock.Eq("fail"),
+		panic:       "other",
+	},
+	"missing-panic": {
+		setup:       test.Errorf("did not panic: want %s", "fail"),
+		expectPanic: "fail",
+	},
+}
+
+func TestRunExpectPanic(t *testing.T) {
+	test.Map(t, expectPanicTestCases).
+		Run(func(t test.Test, param ExpectPanicParams) {
+			// Given
+			mock.NewMocks(t).Expect(param.setup)
+			if param.recover {
+				defer test.Recover(t, param.expectPanic)
+			}
+
+			// When
+			if param.fatal {
+				t.Fatalf("fail")
+			} else if param.panic != nil {
+				panic(param.panic)
+			}
+		})
+}
+
+// budgetSink is a sink to prevent allocations from being optimized away.
+var budgetSink []*int
+
+type BudgetParams struct {
+	setup       mock.SetupFunc
+	maxDuration time.Duration
+	maxAllocs   int
+	sleep       time.Duration
+	allocs      int
+}
+
+var budgetTestCases = map[string]BudgetParams{
+	"no-budget": {
+		sleep:  time.Millisecond,
+		allocs: 100,
+	},
+	"within-budget": {
+		maxDuration: time.Second,
+		maxAllocs:   1 << 20,
+		sleep:       time.Millisecond,
+		allocs:      100,
+	},
+	"default-budget": {
+		maxDuration: -1,
+		maxAllocs:   -1,
+		sleep:       10 * time.Millisecond,
+		allocs:      100,
+	},
+
+	"exceed-duration": {
+		setup: test.Errorf("budget exceeded: duration %v > %v",
+			gomock.Any(), time.Millisecond),
+		maxDuration: time.Millisecond,
+		sleep:       10 * time.Millisecond,
+	},
+	"exceed-allocs": {
+		setup: test.Errorf("budget exceeded: allocs %d > %d",
+			gomock.Any(), 10),
+		maxAllocs: 10,
+		allocs:    100,
+	},
+	"exceed-both": {
+		setup: mock.Chain(
+			test.Errorf("budget exceeded: duration %v > %v",
+				gomock.Any(), time.Millisecond),
+			test.Errorf("budget exceeded: allocs %d > %d",
+				gomock.Any(), 10),
+		),
+		maxDuration: time.Millisecond,
+		maxAllocs:   10,
+		sleep:       10 * time.Millisecond,
+		allocs:      100,
+	},
+}
+
+func TestBudget(t *testing.T) {
+	test.Map(t, budgetTestCases).
+		Budget(time.Second, 1<<20).Slowest(3).
+		RunSeq(func(t test.Test, param BudgetParams) {
+			// Given
+			mock.NewMocks(t).Expect(param.setup)
+
+			// When
+			time.Sleep(param.sleep)
+			budgetSink = make([]*int, 0, param.allocs)
+			for index := range param.allocs {
+				budgetSink = append(budgetSink, &index)
+			}
+		})
+}
+
+func TestBudgetParamValue(t *testing.T) {
+	test.Slice(t, []int{1, 2}).
+		Budget(time.Second, 1<<20).
+		RunSeq(func(_ test.Test, param int) {
+			// When
+			budgetSink = make([]*int, 0, param<<10)
+			for index := range param << 10 {
+				budgetSink = append(budgetSink, &index)
+			}
+		})
+}
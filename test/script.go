@@ -0,0 +1,500 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/txtar"
+
+	"github.com/tkrop/go-testing/mock"
+)
+
+// GoTestingScriptVar is the environment variable used to signal the new
+// process the script command to execute in-process including the working
+// directory and the arguments.
+const GoTestingScriptVar = "GO_TESTING_SCRIPT"
+
+// scriptCall is the script command call provided to the new process.
+type scriptCall struct {
+	// Name is the name of the script command.
+	Name string `json:"name"`
+	// Dir is the working directory of the script command.
+	Dir string `json:"dir"`
+	// Args are the arguments of the script command.
+	Args []string `json:"args"`
+}
+
+var (
+	// ErrScriptUnknownCmd is the error for unknown script commands.
+	ErrScriptUnknownCmd = errors.New("unknown command")
+	// ErrScriptUsage is the error for invalid script command usage.
+	ErrScriptUsage = errors.New("invalid usage")
+	// ErrScriptMismatch is the error for script checks that do not match.
+	ErrScriptMismatch = errors.New("mismatch")
+)
+
+// NewErrScriptUsage creates a new invalid script command usage error.
+func NewErrScriptUsage(usage string) error {
+	return fmt.Errorf("%w [usage: %s]", ErrScriptUsage, usage)
+}
+
+// ScriptCmd defines the common script command function signature. The
+// command is called with the script state and the expanded arguments. A
+// returned error signals a failure of the command.
+type ScriptCmd func(state *ScriptState, args ...string) error
+
+// ScriptParams provides the test parameters for running a script test.
+type ScriptParams struct {
+	// File is the name of the script file used for reporting.
+	File string
+	// Archive is the txtar archive containing the script as comment and the
+	// files that are materialized in the working directory.
+	Archive *txtar.Archive
+}
+
+// ScriptState is the state of a running script test. It is provided to the
+// script commands to access the working directory, the environment, and the
+// captured output of the last command.
+type ScriptState struct {
+	t        Test
+	name     string
+	dir      string
+	env      []string
+	stdout   string
+	stderr   string
+	exitCode int
+	child    bool
+}
+
+// Test returns the test context the script is running in.
+func (s *ScriptState) Test() Test {
+	return s.t
+}
+
+// Dir returns the current working directory of the script.
+func (s *ScriptState) Dir() string {
+	return s.dir
+}
+
+// Path returns the given path resolved against the current working directory
+// of the script.
+func (s *ScriptState) Path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(name))
+}
+
+// Environ returns the environment of the script.
+func (s *ScriptState) Environ() []string {
+	return s.env
+}
+
+// Getenv returns the value of the environment variable with the given key.
+func (s *ScriptState) Getenv(key string) string {
+	for i := len(s.env) - 1; i >= 0; i-- {
+		if value, ok := strings.CutPrefix(s.env[i], key+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// Setenv sets the environment variable with the given key to the given value
+// in the environment of the script.
+func (s *ScriptState) Setenv(key, value string) {
+	s.env = append(s.env, key+"="+value)
+}
+
+// Stdout returns the captured standard output of the last command.
+func (s *ScriptState) Stdout() string {
+	return s.stdout
+}
+
+// Stderr returns the captured standard error of the last command.
+func (s *ScriptState) Stderr() string {
+	return s.stderr
+}
+
+// ExitCode returns the exit code of the last command.
+func (s *ScriptState) ExitCode() int {
+	return s.exitCode
+}
+
+// Output sets the captured standard output and standard error of the current
+// command. This allows custom commands to provide output for checking.
+func (s *ScriptState) Output(stdout, stderr string) {
+	s.stdout, s.stderr = stdout, stderr
+}
+
+// scriptChecks is the set of built-in commands that are checking the output
+// of the last command and thus must not reset the captured output.
+var scriptChecks = map[string]bool{
+	"cmp": true, "stdout": true, "stderr": true,
+	"exit": true, "exists": true,
+}
+
+// scriptCmds is the set of built-in script commands.
+var scriptCmds = map[string]ScriptCmd{
+	"cd":     scriptCd,
+	"env":    scriptEnv,
+	"exec":   scriptExec,
+	"cmp":    scriptCmp,
+	"stdout": scriptMatch((*ScriptState).Stdout),
+	"stderr": scriptMatch((*ScriptState).Stderr),
+	"exit":   scriptExit,
+	"exists": scriptExists,
+}
+
+// ScriptTestCases creates the script test cases from all txtar archive files
+// matching the given glob pattern. The test case name is derived from the
+// file name without extension.
+func ScriptTestCases(pattern string) map[string]ScriptParams {
+	cases := map[string]ScriptParams{}
+	for _, file := range Must(filepath.Glob(pattern)) {
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		cases[name] = ScriptParams{
+			File:    file,
+			Archive: Must(txtar.ParseFile(file)),
+		}
+	}
+	return cases
+}
+
+// Script runs all txtar archive files matching the given glob pattern as
+// script tests in the spirit of the Go script tests. Each script is running
+// in parallel in its own isolated temporary directory using the given custom
+// commands in addition to the built-in commands. See [RunScript] for details.
+func Script(t *testing.T, pattern string, commands map[string]ScriptCmd) {
+	t.Helper()
+
+	Map(t, ScriptTestCases(pattern)).Run(RunScript(commands))
+}
+
+// RunScript creates a test function that runs the script provided as comment
+// of the txtar archive in a new temporary directory containing the files of
+// the archive. Each line of the script contains a command with arguments that
+// can be quoted using single quotes and may contain environment variables
+// (`$VAR`). Empty lines and lines starting with `#` are ignored. A command
+// prefixed by `!` is expected to fail, and a command prefixed by `?` may fail.
+//
+// The following built-in commands are supported:
+//
+//   - `cd dir` changes the working directory of the script,
+//   - `env [key=value...]` sets the environment variables of the script,
+//   - `exec program [args...]` runs the given program capturing its output,
+//   - `cmp file1 file2` compares two files, where `stdout` and `stderr` are
+//     referring to the captured output of the last command,
+//   - `stdout regexp` and `stderr regexp` match the captured output of the
+//     last command against the regular expression,
+//   - `exit code` checks the exit code of the last command, and
+//   - `exists file...` checks that the given files exist.
+//
+// The given custom commands are extending and overriding the built-in commands.
+// The working directory is provided via the `$WORK` environment variable. Use
+// [ScriptMain] to register the `main`-method of the binary as command.
+func RunScript(commands map[string]ScriptCmd) func(t Test, param ScriptParams) {
+	return func(t Test, param ScriptParams) {
+		t.Helper()
+
+		state := &ScriptState{t: t}
+		if name := os.Getenv(GoTestingRunVar); name != "" {
+			// Run only requested script command in process.
+			if name == t.Name() {
+				state.runChild(commands)
+			}
+			return
+		}
+
+		state.dir = FS(t, param.Archive)
+		state.env = append(os.Environ(), "WORK="+state.dir)
+
+		lines := strings.Split(string(param.Archive.Comment), "\n")
+		for index, line := range lines {
+			state.runLine(commands, param.File, index+1, line)
+		}
+	}
+}
+
+// ScriptMain creates a script command that runs the given `main`-method in a
+// separate test process re-executing the test binary similar to [Main]. The
+// script command captures the output as well as the exit code. A `main`
+// method returning without calling `os.Exit` is successful.
+func ScriptMain(main func()) ScriptCmd {
+	return func(state *ScriptState, args ...string) error {
+		if state.child {
+			Must(0, os.Chdir(state.dir))
+			os.Args = append([]string{state.name}, args...)
+			main()
+			os.Exit(0)
+		}
+
+		call := Must(json.Marshal(scriptCall{
+			Name: state.name, Dir: state.dir, Args: args,
+		}))
+
+		// #nosec G204 -- secured by calling only the test instance.
+		cmd := exec.Command(os.Args[0], "-test.run="+
+			scriptRunPattern(state.t.Name()))
+		cmd.Dir = Must(os.Getwd())
+		cmd.Env = append(state.env, GoTestingRunVar+"="+state.t.Name(),
+			GoTestingScriptVar+"="+string(call))
+		return state.run(cmd)
+	}
+}
+
+// scriptRunPattern creates the anchored test run pattern for the given test
+// name.
+func scriptRunPattern(name string) string {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(part) + "$"
+	}
+	return strings.Join(parts, "/")
+}
+
+// runChild runs the script command requested via the environment in the
+// current process.
+func (s *ScriptState) runChild(commands map[string]ScriptCmd) {
+	s.t.Helper()
+
+	call := scriptCall{}
+	Must(0, json.Unmarshal([]byte(os.Getenv(GoTestingScriptVar)), &call))
+	s.name, s.dir, s.child = call.Name, call.Dir, true
+	if command, ok := commands[s.name]; ok {
+		if err := command(s, call.Args...); err != nil {
+			s.t.Fatalf("%s: %v", s.name, err)
+		}
+	}
+}
+
+// runLine runs a single line of the script reporting failures using the file
+// name and the line number.
+func (s *ScriptState) runLine(
+	commands map[string]ScriptCmd, file string, index int, line string,
+) {
+	s.t.Helper()
+
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+
+	negate, lenient := false, false
+	switch {
+	case strings.HasPrefix(line, "!"):
+		negate, line = true, strings.TrimSpace(line[1:])
+	case strings.HasPrefix(line, "?"):
+		lenient, line = true, strings.TrimSpace(line[1:])
+	}
+
+	args := s.parse(line)
+	if len(args) == 0 {
+		return
+	}
+
+	s.name = args[0]
+	command, ok := commands[s.name]
+	if !ok {
+		if command, ok = scriptCmds[s.name]; !ok {
+			s.t.Fatalf("%s:%d: %s: %v", file, index, line, ErrScriptUnknownCmd)
+		}
+	}
+
+	if !scriptChecks[s.name] {
+		s.stdout, s.stderr, s.exitCode = "", "", 0
+	}
+
+	err := command(s, args[1:]...)
+	if !scriptChecks[s.name] && err != nil {
+		s.exitCode = 1
+		if errExit := (&exec.ExitError{}); errors.As(err, &errExit) {
+			s.exitCode = errExit.ExitCode()
+		}
+	}
+
+	switch {
+	case lenient:
+	case negate && err == nil:
+		s.t.Fatalf("%s:%d: %s: unexpected success", file, index, line)
+	case !negate && err != nil:
+		s.t.Fatalf("%s:%d: %s: %v", file, index, line, err)
+	}
+}
+
+// regexScriptArgs is a regular expression to split script lines into single
+// quoted and unquoted arguments.
+var regexScriptArgs = regexp.MustCompile(`'((?:[^']|'')*)'|[^\s']+`)
+
+// parse splits the given script line into arguments expanding environment
+// variables in unquoted arguments.
+func (s *ScriptState) parse(line string) []string {
+	matches := regexScriptArgs.FindAllStringSubmatch(line, -1)
+	args := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if strings.HasPrefix(match[0], "'") {
+			args = append(args, strings.ReplaceAll(match[1], "''", "'"))
+		} else {
+			args = append(args, os.Expand(match[0], s.Getenv))
+		}
+	}
+	return args
+}
+
+// run runs the given command in the working directory of the script capturing
+// the standard output and standard error.
+func (s *ScriptState) run(cmd *exec.Cmd) error {
+	stdout, stderr := &strings.Builder{}, &strings.Builder{}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if cmd.Dir == "" {
+		cmd.Dir = s.dir
+	}
+	if cmd.Env == nil {
+		cmd.Env = s.env
+	}
+
+	err := cmd.Run()
+	s.stdout, s.stderr = stdout.String(), stderr.String()
+	return err //nolint:wrapcheck // transparent wrapper.
+}
+
+// scriptCd changes the working directory of the script.
+func scriptCd(state *ScriptState, args ...string) error {
+	if len(args) != 1 {
+		return NewErrScriptUsage("cd dir")
+	}
+
+	dir := state.Path(args[0])
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err //nolint:wrapcheck // transparent wrapper.
+	} else if !info.IsDir() {
+		return fmt.Errorf("%w [not a directory: %s]", ErrScriptMismatch, dir)
+	}
+	state.dir = dir
+	return nil
+}
+
+// scriptEnv sets the given environment variables of the script. Without
+// arguments the environment is provided as standard output.
+func scriptEnv(state *ScriptState, args ...string) error {
+	if len(args) == 0 {
+		state.stdout = strings.Join(state.env, "\n") + "\n"
+		return nil
+	}
+
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return NewErrScriptUsage("env [key=value...]")
+		}
+		state.Setenv(key, value)
+	}
+	return nil
+}
+
+// scriptExec runs the given program with the given arguments.
+func scriptExec(state *ScriptState, args ...string) error {
+	if len(args) == 0 {
+		return NewErrScriptUsage("exec program [args...]")
+	}
+
+	// #nosec G204 -- intentionally running script programs.
+	return state.run(exec.Command(args[0], args[1:]...))
+}
+
+// scriptCmp compares the content of the given files, where `stdout` and
+// `stderr` are referring to the captured output of the last command.
+func scriptCmp(state *ScriptState, args ...string) error {
+	if len(args) != 2 {
+		return NewErrScriptUsage("cmp file1 file2")
+	}
+
+	got, err := state.read(args[0])
+	if err != nil {
+		return err
+	}
+	want, err := state.read(args[1])
+	if err != nil {
+		return err
+	}
+
+	if got != want {
+		return fmt.Errorf("%w [%s, %s]:\n%s", ErrScriptMismatch,
+			args[0], args[1], mock.NewDiffConfig().Diff(want, got))
+	}
+	return nil
+}
+
+// read reads the content of the given file, where `stdout` and `stderr` are
+// referring to the captured output of the last command.
+func (s *ScriptState) read(name string) (string, error) {
+	switch name {
+	case "stdout":
+		return s.stdout, nil
+	case "stderr":
+		return s.stderr, nil
+	default:
+		data, err := os.ReadFile(s.Path(name))
+		return string(data), err //nolint:wrapcheck // transparent wrapper.
+	}
+}
+
+// scriptMatch creates a command matching the output provided by the given
+// function against the regular expression provided as argument.
+func scriptMatch(output func(*ScriptState) string) ScriptCmd {
+	return func(state *ScriptState, args ...string) error {
+		if len(args) != 1 {
+			return NewErrScriptUsage(state.name + " regexp")
+		}
+
+		regex, err := regexp.Compile("(?m)" + args[0])
+		if err != nil {
+			return err //nolint:wrapcheck // transparent wrapper.
+		}
+
+		if out := output(state); !regex.MatchString(out) {
+			return fmt.Errorf("%w [%s: %q]:\n%s", ErrScriptMismatch,
+				state.name, args[0], out)
+		}
+		return nil
+	}
+}
+
+// scriptExit checks the exit code of the last command.
+func scriptExit(state *ScriptState, args ...string) error {
+	if len(args) != 1 {
+		return NewErrScriptUsage("exit code")
+	}
+
+	code, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err //nolint:wrapcheck // transparent wrapper.
+	} else if code != state.exitCode {
+		return fmt.Errorf("%w [exit: want %d, got %d]", ErrScriptMismatch,
+			code, state.exitCode)
+	}
+	return nil
+}
+
+// scriptExists checks that all given files exist.
+func scriptExists(state *ScriptState, args ...string) error {
+	if len(args) == 0 {
+		return NewErrScriptUsage("exists file...")
+	}
+
+	for _, arg := range args {
+		if _, err := os.Lstat(state.Path(arg)); err != nil {
+			return err //nolint:wrapcheck // transparent wrapper.
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package test_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"golang.org/x/tools/txtar"
+
+	"github.com/tkrop/go-testing/mock"
+	"github.com/tkrop/go-testing/test"
+)
+
+// scriptMain is a test main function to demonstrate the usage of the
+// `test.ScriptMain` command.
+func scriptMain() {
+	fmt.Fprintf(os.Stdout, "args=%v\n", os.Args)
+	if len(os.Args) > 1 && os.Args[1] == "fail" {
+		fmt.Fprintln(os.Stderr, "failed")
+		os.Exit(2)
+	}
+}
+
+// scriptGreet is a custom script command for testing.
+func scriptGreet(state *test.ScriptState, args ...string) error {
+	if len(args) != 1 {
+		return test.NewErrScriptUsage("greet name")
+	}
+	state.Output("hello "+args[0]+"\n", "")
+	return nil
+}
+
+// scriptCommands are the custom script commands used for testing.
+var scriptCommands = map[string]test.ScriptCmd{
+	"greet": scriptGreet,
+	"main":  test.ScriptMain(scriptMain),
+}
+
+func TestScript(t *testing.T) {
+	test.Script(t, "testdata/script/*.txtar", scriptCommands)
+}
+
+type ScriptParams struct {
+	setup  mock.SetupFunc
+	script string
+}
+
+var scriptTestCases = map[string]ScriptParams{
+	"empty": {
+		script: "",
+	},
+	"comments": {
+		script: "# comment\n\n  # indented comment\n",
+	},
+	"unknown-command": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 2,
+			"unknown arg", test.ErrScriptUnknownCmd),
+		script: "\nunknown arg",
+	},
+	"unexpected-success": {
+		setup: test.Fatalf("%s:%d: %s: unexpected success",
+			"script.txtar", 1, "greet world"),
+		script: "! greet world",
+	},
+	"usage-cd": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"cd", test.NewErrScriptUsage("cd dir")),
+		script: "cd",
+	},
+	"usage-env": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"env invalid", test.NewErrScriptUsage("env [key=value...]")),
+		script: "env invalid",
+	},
+	"usage-exec": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"exec", test.NewErrScriptUsage("exec program [args...]")),
+		script: "exec",
+	},
+	"usage-cmp": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"cmp stdout", test.NewErrScriptUsage("cmp file1 file2")),
+		script: "cmp stdout",
+	},
+	"usage-stdout": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"stdout", test.NewErrScriptUsage("stdout regexp")),
+		script: "stdout",
+	},
+	"usage-exit": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"exit", test.NewErrScriptUsage("exit code")),
+		script: "exit",
+	},
+	"usage-exists": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"exists", test.NewErrScriptUsage("exists file...")),
+		script: "exists",
+	},
+	"cd-no-directory": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"cd file.txt", gomock.Any()),
+		script: "cd file.txt",
+	},
+	"cd-missing": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"cd missing", gomock.Any()),
+		script: "cd missing",
+	},
+	"cmp-mismatch": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 2,
+			"cmp stdout file.txt", gomock.Any()),
+		script: "greet world\ncmp stdout file.txt",
+	},
+	"cmp-missing": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"cmp missing file.txt", gomock.Any()),
+		script: "cmp missing file.txt",
+	},
+	"stdout-mismatch": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 2,
+			"stdout ^other$", gomock.Any()),
+		script: "greet world\nstdout ^other$",
+	},
+	"stdout-invalid": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"stdout (", gomock.Any()),
+		script: "stdout (",
+	},
+	"exit-mismatch": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"exit 1", gomock.Any()),
+		script: "exit 1",
+	},
+	"exit-invalid": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"exit x", gomock.Any()),
+		script: "exit x",
+	},
+	"exists-missing": {
+		setup: test.Fatalf("%s:%d: %s: %v", "script.txtar", 1,
+			"exists missing", gomock.Any()),
+		script: "exists missing",
+	},
+	"env-output": {
+		script: "env KEY=value\nenv\nstdout ^KEY=value$",
+	},
+	"quoted-args": {
+		script: "greet 'it''s $WORK'\nstdout '^hello it''s \\$WORK$'",
+	},
+}
+
+func TestRunScript(t *testing.T) {
+	test.Map(t, scriptTestCases).
+		Run(func(t test.Test, param ScriptParams) {
+			// Given
+			mock.NewMocks(t).Expect(param.setup)
+			archive := &txtar.Archive{
+				Comment: []byte(param.script),
+				Files: []txtar.File{
+					{Name: "file.txt", Data: []byte("content\n")},
+				},
+			}
+
+			// When
+			test.RunScript(scriptCommands)(t, test.ScriptParams{
+				File: "script.txtar", Archive: archive,
+			})
+		})
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"strings"
+)
+
+// Soft runs the given test function against a soft child test context that
+// collects all failures instead of aborting the test function on the first
+// fatal failure, e.g. on a `FailNow` triggered by a `require` assertion.
+// After the test function has finished, all collected failures are reported
+// together as a single structured failure to the parent test context. Only
+// panics still abort the test function. The function returns whether no
+// failure has been collected.
+func Soft(t Test, test Func) bool {
+	t.Helper()
+
+	if failures := softRun(t, test, true); len(failures) != 0 {
+		t.Errorf("soft assertion failures [%d]:%s",
+			len(failures), strings.Join(failures, ""))
+		return false
+	}
+	return true
+}
+
+// softRun runs the given test function against an isolated child test context
+// that records all failures instead of reporting them to the parent test
+// context. If soft is set, fatal failures are not aborting the test function.
+// The function returns the formatted failures in order of occurrence.
+func softRun(t Test, test Func, soft bool) []string {
+	t.Helper()
+
+	recorder := &FailureRecorder{Test: t, soft: soft}
+	tx := New(t, !Parallel).Expect(Failure)
+	tx.Reporter(recorder)
+	tx.logs, tx.wg, tx.soft = &logBuffer{closed: true}, nil, soft
+
+	done := make(chan any, 1)
+	go tx.run(func(t Test) {
+		defer tx.cleanup()
+		test(t)
+	}, done)
+	<-done
+
+	failures, last := []string{}, Report{}
+	for _, report := range recorder.Reports() {
+		if report.IsLog() {
+			continue
+		} else if !softDuplicate(last, report) {
+			failures = append(failures, "\n\t"+
+				strings.ReplaceAll(report.String(), "\n", "\n\t\t"))
+		}
+		last = report
+	}
+	return failures
+}
+
+// softDuplicate returns whether the given report is a failure notification
+// without message directly following a failure with message at the same
+// caller location, as created by `require` assertions.
+func softDuplicate(last, report Report) bool {
+	return (report.Method == "Fail" || report.Method == "FailNow") &&
+		report.Message == "" && last.Message != "" &&
+		last.Caller == report.Caller
+}
+
+// cleanup runs the registered cleanup functions of the test context in
+// reverse order of registration.
+func (t *Context) cleanup() {
+	t.mu.Lock()
+	cleanups := t.cleanups
+	t.cleanups = nil
+	t.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
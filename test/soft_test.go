@@ -0,0 +1,116 @@
+package test_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tkrop/go-testing/test"
+)
+
+type SoftParams struct {
+	call         test.Func
+	expect       *regexp.Regexp
+	expectResult bool
+	expectLogs   []string
+}
+
+var softTestCases = map[string]SoftParams{
+	"no-failures": {
+		call: func(t test.Test) {
+			assert.Equal(t, 1, 1)
+			require.Equal(t, "a", "a")
+		},
+		expectResult: true,
+	},
+	"log-only": {
+		call: func(t test.Test) {
+			t.Log("log message")
+		},
+		expectResult: true,
+		expectLogs:   []string{"log message"},
+	},
+	"error-failures": {
+		call: func(t test.Test) {
+			t.Errorf("error %d", 1)
+			t.Errorf("error %d", 2)
+		},
+		expect: regexp.MustCompile(`^soft assertion failures \[2\]:` +
+			`\n\tsoft_test.go:[0-9]+: Errorf: error 1` +
+			`\n\tsoft_test.go:[0-9]+: Errorf: error 2$`),
+	},
+	"fatal-failures": {
+		call: func(t test.Test) {
+			t.Fatalf("fatal %d", 1)
+			t.FailNow()
+			t.Fatal("fatal", 2)
+		},
+		expect: regexp.MustCompile(`^soft assertion failures \[3\]:` +
+			`\n\tsoft_test.go:[0-9]+: Fatalf: fatal 1` +
+			`\n\tsoft_test.go:[0-9]+: FailNow: ` +
+			`\n\tsoft_test.go:[0-9]+: Fatal: fatal 2$`),
+	},
+	"require-failures": {
+		call: func(t test.Test) {
+			require.Equal(t, 1, 2)
+			assert.Equal(t, "a", "b")
+			require.NoError(t, assert.AnError)
+		},
+		expect: regexp.MustCompile(`(?s)^soft assertion failures \[3\]:` +
+			`\n\tsoft_test.go:[0-9]+: Errorf: \s*Error Trace:.*` +
+			`expected: 1\n.*actual  : 2\n.*` +
+			`\n\tsoft_test.go:[0-9]+: Errorf: \s*Error Trace:.*` +
+			`expected: "a"\n.*actual  : "b"\n.*` +
+			`\n\tsoft_test.go:[0-9]+: Errorf: \s*Error Trace:.*` +
+			`Received unexpected error:.*$`),
+	},
+	"panic-failure": {
+		call: func(t test.Test) {
+			t.Errorf("error")
+			panic("panic")
+		},
+		expect: regexp.MustCompile(`^soft assertion failures \[2\]:` +
+			`\n\tsoft_test.go:[0-9]+: Errorf: error` +
+			`\n\tsoft_test.go:[0-9]+: Panic: panic$`),
+	},
+	"cleanup-failure": {
+		call: func(t test.Test) {
+			t.Cleanup(func() { t.Errorf("cleanup") })
+			t.Errorf("error")
+		},
+		expect: regexp.MustCompile(`^soft assertion failures \[2\]:` +
+			`\n\tsoft_test.go:[0-9]+: Errorf: error` +
+			`\n\tsoft_test.go:[0-9]+: Errorf: cleanup$`),
+	},
+}
+
+func TestSoft(t *testing.T) {
+	test.Map(t, softTestCases).
+		Run(func(t test.Test, param SoftParams) {
+			// Given
+			recorder := test.NewFailureRecorder(t)
+			result := false
+
+			// When
+			recorder.Run(func(t test.Test) {
+				result = test.Soft(t, param.call)
+			})
+
+			// Then
+			assert.Equal(t, param.expectResult, result)
+			logs := []string{}
+			for _, report := range recorder.Reports() {
+				if report.IsLog() {
+					logs = append(logs, report.Message)
+				}
+			}
+			assert.Equal(t, append([]string{}, param.expectLogs...), logs)
+			if param.expect != nil {
+				recorder.AssertFailures(t, param.expect)
+			} else {
+				recorder.AssertFailures(t)
+			}
+		})
+}